@@ -0,0 +1,132 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*AddrOpt)(nil)
+	_ EnvOpt  = (*AddrOpt)(nil)
+	_ FlagOpt = (*AddrOpt)(nil)
+	_ Getter  = (*AddrOpt)(nil)
+	_ Setter  = (*AddrOpt)(nil)
+	_ TomlOpt = (*AddrOpt)(nil)
+)
+
+// AddrOpt represents a host:port address Option.
+type AddrOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *string
+}
+
+// NewAddrOpt instantiates a AddrOpt and returns needed implementation details.
+func NewAddrOpt(name string, sname rune, val string, help string) (*AddrOpt, *string) {
+	out := AddrOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *AddrOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *AddrOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*AddrOpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *AddrOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *AddrOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *AddrOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *AddrOpt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *AddrOpt) Set(vv interface{}) error {
+	var (
+		val string
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseAddr(v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *AddrOpt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Addr adds a AddrOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Addr(name string, sname rune, val string, help string) *string {
+	op, v := NewAddrOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// AddrVar adds a AddrOpt to the OptionSet.
+func (o *OptionSet) AddrVar(out *string, name string, sname rune, val string, help string) {
+	*out = val
+	op := &AddrOpt{help, name, sname, out}
+	o.Var(op)
+}
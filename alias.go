@@ -0,0 +1,99 @@
+package libuconf
+
+// ensure interface compliance
+var (
+	_ FlagOpt      = (*aliasOpt)(nil)
+	_ NegatableOpt = (*aliasOpt)(nil)
+)
+
+// aliasOpt is an additional name for a FlagOpt.
+// It is only a FlagOpt, so other systems never see it.
+type aliasOpt struct {
+	FlagOpt
+	name       string
+	sname      rune
+	deprecated bool
+}
+
+// Alias adds an additional long flag (name) and/or short flag (sname) for the
+// option whose long flag is flag.
+// Either may be empty (0 for sname).
+//
+// The alias is annotated as such in Usage.
+// If there is no such option, ErrUnknownFlag is returned.
+func (o *OptionSet) Alias(flag, name string, sname rune) error {
+	return o.alias(flag, name, sname, false)
+}
+
+// DeprecatedAlias is like Alias, but using the alias will also report
+// ErrDeprecated (naming the replacement) to OptionSet.Warn.
+// The value is still set.
+func (o *OptionSet) DeprecatedAlias(flag, name string, sname rune) error {
+	return o.alias(flag, name, sname, true)
+}
+
+func (o *OptionSet) alias(flag, name string, sname rune, deprecated bool) error {
+	var target FlagOpt
+	o.VisitFlag(func(f FlagOpt) {
+		if f.Flag() == flag {
+			target = unalias(f)
+		}
+	})
+	if target == nil {
+		return errUnknownFlag("--"+flag, "")
+	}
+	o.Var(&aliasOpt{target, name, sname, deprecated})
+	return nil
+}
+
+// warnDeprecated warns if f is a deprecated alias.
+// prefix is how it was given: "-" (as a short flag), "--" or "--no-".
+func (o *OptionSet) warnDeprecated(f FlagOpt, prefix string) {
+	v, ok := f.(*aliasOpt)
+	if !ok || !v.deprecated {
+		return
+	}
+	flag, repl := prefix+v.name, "--"+v.FlagOpt.Flag()
+	switch prefix {
+	case "-":
+		flag = "-" + string(v.sname)
+	case "--no-":
+		repl = "--no-" + v.FlagOpt.Flag()
+	}
+	o.warn(errDeprecated(flag, repl))
+}
+
+// unalias returns the option an alias refers to, or f if it isn't an alias.
+func unalias(f FlagOpt) FlagOpt {
+	if v, ok := f.(*aliasOpt); ok {
+		return v.FlagOpt
+	}
+	return f
+}
+
+// ---- FlagOpt
+
+// Flag returns the alias' long flag.
+func (r *aliasOpt) Flag() string {
+	return r.name
+}
+
+// Help returns a note pointing to the aliased option.
+func (r *aliasOpt) Help() string {
+	if r.deprecated {
+		return "deprecated, use --" + r.FlagOpt.Flag()
+	}
+	return "alias for --" + r.FlagOpt.Flag()
+}
+
+// ShortFlag returns the alias' short flag.
+func (r *aliasOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- NegatableOpt
+
+// Negatable returns whether or not the aliased option is negatable.
+func (r *aliasOpt) Negatable() bool {
+	return negatable(r.FlagOpt)
+}
@@ -0,0 +1,134 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*BoolOpt)(nil)
+	_ EnvOpt  = (*BoolOpt)(nil)
+	_ FlagOpt = (*BoolOpt)(nil)
+	_ Getter  = (*BoolOpt)(nil)
+	_ Setter  = (*BoolOpt)(nil)
+	_ TomlOpt = (*BoolOpt)(nil)
+)
+
+// BoolOpt represents a boolean Option.
+type BoolOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *bool
+}
+
+// NewBoolOpt instantiates a BoolOpt and returns needed implementation details.
+func NewBoolOpt(name string, sname rune, val bool, help string) (*BoolOpt, *bool) {
+	out := BoolOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *BoolOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *BoolOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*BoolOpt) Bool() bool {
+	return true
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *BoolOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *BoolOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *BoolOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *BoolOpt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *BoolOpt) Set(vv interface{}) error {
+	var (
+		val bool
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseBool(v)
+	case bool:
+		val = v
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *BoolOpt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Bool adds a BoolOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Bool(name string, sname rune, val bool, help string) *bool {
+	op, v := NewBoolOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// BoolVar adds a BoolOpt to the OptionSet.
+func (o *OptionSet) BoolVar(out *bool, name string, sname rune, val bool, help string) {
+	*out = val
+	op := &BoolOpt{help, name, sname, out}
+	o.Var(op)
+}
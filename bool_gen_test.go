@@ -0,0 +1,56 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+)
+
+func TestBoolOpt(t *testing.T) {
+	var val bool
+	op, p := NewBoolOpt("a.b-c", 'x', val, "help")
+	if op.Flag() != "a.b-c" || op.ShortFlag() != 'x' || op.Help() != "help" {
+		t.Errorf("got flags %q, %q and help %q", op.Flag(), op.ShortFlag(), op.Help())
+	}
+	if op.Env() != "A_B-C" || op.Toml() != "a.b-c" || op.File() != "a.b-c" {
+		t.Errorf("got env %q, toml %q and file %q", op.Env(), op.Toml(), op.File())
+	}
+	if op.Bool() != true {
+		t.Errorf("got Bool() %v", op.Bool())
+	}
+	if !reflect.DeepEqual(op.Get(), val) || !reflect.DeepEqual(*p, val) {
+		t.Errorf("got %+v, want %+v", op.Get(), val)
+	}
+	if err := op.Set(val); err != nil {
+		t.Errorf("setting to a bool: %v", err)
+	}
+	if err := op.Set("TRUE"); err != nil {
+		t.Errorf("setting to %q: %v", "TRUE", err)
+	}
+	if err := op.Set("yes"); !errors.Is(err, ErrSet) {
+		t.Errorf("setting to %q: got %v, want ErrSet", "yes", err)
+	}
+	if err := op.Set(struct{}{}); !errors.Is(err, ErrSet) {
+		t.Errorf("setting to a struct: got %v, want ErrSet", err)
+	}
+}
+
+func TestBoolIntegration(t *testing.T) {
+	var (
+		o   = &OptionSet{AppName: "test"}
+		val bool
+		out bool
+	)
+	p := o.Bool("a", 'a', val, "ahelp")
+	o.BoolVar(&out, "b", 'b', val, "bhelp")
+	if !reflect.DeepEqual(*p, val) || !reflect.DeepEqual(out, val) {
+		t.Errorf("got %+v and %+v, want %+v", *p, out, val)
+	}
+	if o.FindLongFlag("a") == nil || o.FindShortFlag('b') == nil {
+		t.Error("options were not added to the OptionSet")
+	}
+}
@@ -1,44 +1,36 @@
 package libuconf
 
+import (
+	"net"
+	"net/url"
+)
+
 /*
 In this file we define various OptionSet integrations for the built-in Options.
 The Type() functions are glorified wrappers around NewTypeOpt().
 The TypeVar() functions are special handling, meant to emulate go's flag lib.
 
-This will likely all be generated later.
+Most types are generated by cmd/uconfgen instead, along with their
+integrations, see generate.go.
 */
 
-// ---- bool
-
-// Bool adds a BoolOpt to the OptionSet.
-// It returns a pointer to the output value.
-func (o *OptionSet) Bool(name string, sname rune, val bool, help string) *bool {
-	op, v := NewBoolOpt(name, sname, val, help)
-	o.Var(op)
-	return v
-}
-
-// BoolVar adds a BoolOpt to the OptionSet.
-func (o *OptionSet) BoolVar(out *bool, name string, sname rune, val bool, help string) {
-	*out = val
-	op := &BoolOpt{help, name, sname, out}
-	o.Var(op)
-}
-
-// ---- float
+// ---- cidr
 
-// Float adds a FloatOpt to the OptionSet.
+// CIDR adds a CIDROpt to the OptionSet.
 // It returns a pointer to the output value.
-func (o *OptionSet) Float(name string, sname rune, val float64, help string) *float64 {
-	op, v := NewFloatOpt(name, sname, val, help)
+func (o *OptionSet) CIDR(name string, sname rune, val *net.IPNet, help string) *net.IPNet {
+	op, v := NewCIDROpt(name, sname, val, help)
 	o.Var(op)
 	return v
 }
 
-// FloatVar adds a FloatOpt to the OptionSet.
-func (o *OptionSet) FloatVar(out *float64, name string, sname rune, val float64, help string) {
-	*out = val
-	op := &FloatOpt{help, name, sname, out}
+// CIDRVar adds a CIDROpt to the OptionSet.
+func (o *OptionSet) CIDRVar(out *net.IPNet, name string, sname rune, val *net.IPNet, help string) {
+	*out = net.IPNet{} // val may be nil
+	if val != nil {
+		*out = *val
+	}
+	op := &CIDROpt{help, name, sname, out}
 	o.Var(op)
 }
 
@@ -51,53 +43,48 @@ func (o *OptionSet) Help() *bool {
 	return out
 }
 
-// ---- int
-
-// Int adds a IntOpt to the OptionSet.
-// It returns a pointer to the output value.
-func (o *OptionSet) Int(name string, sname rune, val int64, help string) *int64 {
-	op, v := NewIntOpt(name, sname, val, help)
-	o.Var(op)
-	return v
-}
-
-// IntVar adds a IntOpt to the OptionSet.
-func (o *OptionSet) IntVar(out *int64, name string, sname rune, val int64, help string) {
-	*out = val
-	op := &IntOpt{help, name, sname, out}
-	o.Var(op)
+// HelpAll adds a --help-all flag to the OptionSet.
+func (o *OptionSet) HelpAll() *bool {
+	out := new(bool)
+	o.Var((*HelpAllOpt)(out))
+	return out
 }
 
-// ---- string
+// ---- secret
 
-// String adds a StringOpt to the OptionSet.
+// Secret adds a SecretOpt to the OptionSet.
 // It returns a pointer to the output value.
-func (o *OptionSet) String(name string, sname rune, val string, help string) *string {
-	op, v := NewStringOpt(name, sname, val, help)
+func (o *OptionSet) Secret(name string, sname rune, val string, help string) *string {
+	op, v := NewSecretOpt(name, sname, val, help)
 	o.Var(op)
 	return v
 }
 
-// StringVar adds a StringOpt to the OptionSet.
-func (o *OptionSet) StringVar(out *string, name string, sname rune, val string, help string) {
+// SecretVar adds a SecretOpt to the OptionSet.
+func (o *OptionSet) SecretVar(out *string, name string, sname rune, val string, help string) {
 	*out = val
-	op := &StringOpt{help, name, sname, out}
+	op := &SecretOpt{help, name, sname, out}
 	o.Var(op)
 }
 
-// ---- uint
+// ---- url
 
-// Uint adds a UintOpt to the OptionSet.
+// URL adds a URLOpt to the OptionSet.
 // It returns a pointer to the output value.
-func (o *OptionSet) Uint(name string, sname rune, val uint64, help string) *uint64 {
-	op, v := NewUintOpt(name, sname, val, help)
+// If any schemes are given, the URL must use one of them.
+func (o *OptionSet) URL(name string, sname rune, val *url.URL, help string, schemes ...string) *url.URL {
+	op, v := NewURLOpt(name, sname, val, help, schemes...)
 	o.Var(op)
 	return v
 }
 
-// UintVar adds a UintOpt to the OptionSet.
-func (o *OptionSet) UintVar(out *uint64, name string, sname rune, val uint64, help string) {
-	*out = val
-	op := &UintOpt{help, name, sname, out}
+// URLVar adds a URLOpt to the OptionSet.
+// If any schemes are given, the URL must use one of them.
+func (o *OptionSet) URLVar(out *url.URL, name string, sname rune, val *url.URL, help string, schemes ...string) {
+	*out = url.URL{} // val may be nil
+	if val != nil {
+		*out = *val
+	}
+	op := &URLOpt{help, name, schemes, sname, out}
 	o.Var(op)
 }
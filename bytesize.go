@@ -0,0 +1,100 @@
+package libuconf
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/*
+ByteSize is a size in bytes, as held by ByteSizeOpt.
+It is printed in human-readable form, such as "10MiB" (see String).
+
+It is parsed from a number (which may have a fractional part), optionally
+followed by a unit, with or without a space in between.
+Units are case insensitive, and may end in "B".
+SI units are powers of 1000: k (or kB), M, G, T, P and E.
+IEC units are powers of 1024: Ki (or KiB), Mi, Gi, Ti, Pi and Ei.
+For example, "10MiB" is 10485760 bytes, and "1.5G" is 1500000000 bytes.
+Sizes must be a whole number of bytes, and fit in a uint64.
+Plain numbers (such as from TOML) are taken as bytes.
+*/
+type ByteSize uint64
+
+// byteUnits lists every unit, largest first, so that String picks the
+// largest one that fits.
+var byteUnits = []struct {
+	name string
+	size uint64
+}{
+	{"EiB", 1 << 60}, {"EB", 1e18},
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"kB", 1e3},
+	{"B", 1},
+}
+
+// String returns the size in human-readable form, such as "10MiB".
+// It uses the largest unit that divides the size, so that parsing it back
+// gives the same size, which means sizes like 1500 are shown as "1500B".
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if u.size > 1 && uint64(b) >= u.size && uint64(b)%u.size == 0 {
+			return strconv.FormatUint(uint64(b)/u.size, 10) + u.name
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// parseByteSize parses s as described in ByteSize.
+func parseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.TrimSpace(s[i:])
+	if num == "" {
+		return 0, errors.New("missing number")
+	}
+
+	size, ok := byteUnit(unit)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, fmt.Errorf("invalid number %q", num)
+	}
+	r.Mul(r, new(big.Rat).SetUint64(size))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("%s is not a whole number of bytes", s)
+	}
+	if !r.Num().IsUint64() {
+		return 0, fmt.Errorf("%s is too large", s)
+	}
+	return ByteSize(r.Num().Uint64()), nil
+}
+
+// byteUnit returns the size of unit, which may omit the trailing "B".
+func byteUnit(unit string) (uint64, bool) {
+	unit = strings.ToLower(unit)
+	if unit == "" || unit == "b" {
+		return 1, true
+	}
+	if !strings.HasSuffix(unit, "b") {
+		unit += "b"
+	}
+	for _, u := range byteUnits {
+		if strings.ToLower(u.name) == unit {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,136 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*ByteSizeOpt)(nil)
+	_ EnvOpt  = (*ByteSizeOpt)(nil)
+	_ FlagOpt = (*ByteSizeOpt)(nil)
+	_ Getter  = (*ByteSizeOpt)(nil)
+	_ Setter  = (*ByteSizeOpt)(nil)
+	_ TomlOpt = (*ByteSizeOpt)(nil)
+)
+
+// ByteSizeOpt represents a ByteSize Option.
+type ByteSizeOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *ByteSize
+}
+
+// NewByteSizeOpt instantiates a ByteSizeOpt and returns needed implementation details.
+func NewByteSizeOpt(name string, sname rune, val ByteSize, help string) (*ByteSizeOpt, *ByteSize) {
+	out := ByteSizeOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *ByteSizeOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *ByteSizeOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*ByteSizeOpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *ByteSizeOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *ByteSizeOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *ByteSizeOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *ByteSizeOpt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *ByteSizeOpt) Set(vv interface{}) error {
+	var (
+		val ByteSize
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseByteSize(v)
+	case ByteSize:
+		val = v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		val, err = toUint[ByteSize](v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *ByteSizeOpt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// ByteSize adds a ByteSizeOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) ByteSize(name string, sname rune, val ByteSize, help string) *ByteSize {
+	op, v := NewByteSizeOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// ByteSizeVar adds a ByteSizeOpt to the OptionSet.
+func (o *OptionSet) ByteSizeVar(out *ByteSize, name string, sname rune, val ByteSize, help string) {
+	*out = val
+	op := &ByteSizeOpt{help, name, sname, out}
+	o.Var(op)
+}
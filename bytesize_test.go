@@ -0,0 +1,81 @@
+package libuconf_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteSize(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		b      = o.ByteSize("buffer", 'b', 4096, "bufferhelp")
+	)
+	for _, v := range []struct {
+		in  string
+		out ByteSize
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"10MiB", 10 << 20},
+		{"10 mib", 10 << 20},
+		{"1.5G", 1500000000},
+		{"1.5GB", 1500000000},
+		{"2k", 2000},
+		{"2KiB", 2048},
+		{"0.5Ki", 512},
+	} {
+		assert.Nil(o.ParseFlags([]string{"--buffer", v.in}), v.in)
+		assert.Equal(v.out, *b, v.in)
+	}
+
+	assert.Nil(o.ParseTomlString("buffer = 1024\n"))
+	assert.Equal(ByteSize(1024), *b)
+	assert.Nil(o.ParseTomlString(`buffer = "64 MiB"`))
+	assert.Equal(ByteSize(64<<20), *b)
+
+	for _, v := range []struct {
+		in  string
+		msg string
+	}{
+		{"10XB", "failed to set: buffer to 10XB: unknown unit \"XB\""},
+		{"MiB", "failed to set: buffer to MiB: missing number"},
+		{"1.5", "failed to set: buffer to 1.5: 1.5 is not a whole number of bytes"},
+		{"16EiB", "failed to set: buffer to 16EiB: 16EiB is too large"},
+		{"1..5k", "failed to set: buffer to 1..5k: invalid number \"1..5\""},
+	} {
+		err := o.ParseFlags([]string{"--buffer=" + v.in})
+		assert.True(errors.Is(err, ErrSet), v.in)
+		assert.EqualError(err, v.msg)
+	}
+	assert.True(errors.Is(o.ParseTomlString("buffer = -1"), ErrSet))
+	assert.Equal(ByteSize(64<<20), *b)
+}
+
+func TestByteSizeString(t *testing.T) {
+	assert := assert.New(t)
+	for in, out := range map[uint64]string{
+		0:          "0B",
+		512:        "512B",
+		1000:       "1kB",
+		1024:       "1KiB",
+		1500:       "1500B",
+		10 << 20:   "10MiB",
+		1500000000: "1500MB",
+		1234567:    "1234567B",
+		1 << 62:    "4EiB",
+	} {
+		assert.Equal(out, ByteSize(in).String())
+		op, b := NewByteSizeOpt("buffer", 0, 0, "")
+		assert.Nil(op.Set(out), out) // what Usage shows parses back
+		assert.Equal(ByteSize(in), *b, out)
+	}
+
+	op, _ := NewByteSizeOpt("buffer", 0, 3<<30, "")
+	assert.Equal("3GiB", fmt.Sprint(op.Get()))
+}
@@ -0,0 +1,126 @@
+package libuconf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*CIDROpt)(nil)
+	_ EnvOpt  = (*CIDROpt)(nil)
+	_ FlagOpt = (*CIDROpt)(nil)
+	_ Getter  = (*CIDROpt)(nil)
+	_ Setter  = (*CIDROpt)(nil)
+	_ TomlOpt = (*CIDROpt)(nil)
+)
+
+// CIDROpt represents a network Option, written in CIDR notation (such as
+// "192.0.2.0/24").
+type CIDROpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *net.IPNet
+}
+
+// NewCIDROpt instantiates a CIDROpt and returns needed implementation details.
+// val may be nil, in which case the network is empty.
+func NewCIDROpt(name string, sname rune, val *net.IPNet, help string) (*CIDROpt, *net.IPNet) {
+	out := CIDROpt{help, name, sname, new(net.IPNet)}
+	if val != nil {
+		*out.val = *val
+	}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *CIDROpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *CIDROpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*CIDROpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *CIDROpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *CIDROpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *CIDROpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the network as a string, or "" if there is none.
+// This is primarily used in Usage() to show the current value for options.
+func (r *CIDROpt) Get() interface{} {
+	if r.val.IP == nil {
+		return ""
+	}
+	return r.val.String()
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *CIDROpt) Set(vv interface{}) error {
+	switch v := vv.(type) {
+	case string:
+		_, n, e := net.ParseCIDR(v)
+		if e != nil {
+			return errSetTo(v, errors.New("invalid CIDR network"))
+		}
+		*r.val = *n
+	case net.IPNet:
+		*r.val = v
+	case *net.IPNet:
+		if v == nil {
+			return fmt.Errorf("%w: to %+v", ErrSet, vv)
+		}
+		*r.val = *v
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *CIDROpt) Toml() string {
+	return _toml(r)
+}
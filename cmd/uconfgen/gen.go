@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// libuconf is the import path of the package we generate options for.
+const libuconf = "toast.cafe/x/libuconf"
+
+// spec describes an option type to generate.
+type spec struct {
+	Name    string // Int8
+	Type    string // int8
+	Parse   string // func(string) (Type, error)
+	Convs   []conversion
+	Default string // func(interface{}) (Type, error), for any other type
+	Get     string // func(Type) interface{}, what Get returns
+	Doc     string // a signed 8-bit integer
+	Bool    bool
+	NoNeg   bool // Bool, but "--no-flag" is not valid (see NegatableOpt)
+	Imports []string
+	Package string
+	Valid   string // a string that should parse, for tests
+	Invalid string // a string that should not parse, for tests
+}
+
+// conversion is a function converting From into the spec's Type.
+type conversion struct {
+	From []string
+	Func string // func(From) (Type, error), or func(interface{}) (Type, error)
+}
+
+// Case is the list of types for the conversion's case in Set.
+func (c conversion) Case() string {
+	return strings.Join(c.From, ", ")
+}
+
+// check ensures s is usable, and fills in defaults.
+func (s *spec) check() error {
+	switch {
+	case !token.IsIdentifier(s.Name) || !token.IsExported(s.Name):
+		return errors.New("-type must be an exported identifier")
+	case s.Type == "":
+		return errors.New("-go is required")
+	case s.Parse == "":
+		return errors.New("-parse is required")
+	case s.Package == "":
+		return errors.New("-pkg is required outside of go generate")
+	}
+	if s.Doc == "" {
+		s.Doc = "a " + s.Type
+	}
+	if s.NoNeg && !s.Bool {
+		return errors.New("-negatable=false requires -bool")
+	}
+	for i := 0; i < len(s.Convs); i++ {
+		v := s.Convs[i]
+		if len(v.From) == 1 && v.From[0] == "*" { // the default case
+			if s.Default != "" {
+				return errors.New("more than one default conversion")
+			}
+			s.Default = v.Func
+			s.Convs = append(s.Convs[:i], s.Convs[i+1:]...)
+			i--
+			continue
+		}
+		for _, from := range v.From {
+			if from == "" || from == "*" {
+				return errors.New("invalid type in -conv: " + from)
+			}
+			if from == s.Type || from == "string" {
+				return errors.New("cannot convert from " + from + ", it is always handled")
+			}
+		}
+	}
+	return nil
+}
+
+// Internal reports whether we are generating for libuconf itself.
+func (s *spec) Internal() bool {
+	return s.Package == "libuconf"
+}
+
+// Q is the qualifier for libuconf identifiers.
+func (s *spec) Q() string {
+	if s.Internal() {
+		return ""
+	}
+	return "libuconf."
+}
+
+// Lower is the name used in section comments, as in "// ---- int8".
+func (s *spec) Lower() string {
+	return strings.ToLower(s.Name)
+}
+
+// StringType reports whether the Go type is string, in which case it must not
+// get its own case in Set.
+func (s *spec) StringType() bool {
+	return s.Type == "string"
+}
+
+func (s *spec) generate() ([]byte, error) {
+	imports := append([]string(nil), s.Imports...)
+	if s.Default == "" || !s.Internal() { // for errors
+		imports = append(imports, "fmt")
+	}
+	if !s.Internal() {
+		imports = append(imports, "strings", libuconf)
+	}
+	return s.execute(optTemplate, imports)
+}
+
+func (s *spec) generateTest() ([]byte, error) {
+	imports := append([]string{"reflect", "testing"}, s.Imports...)
+	if s.Default == "" || s.Invalid != "" { // for errors.Is
+		imports = append(imports, "errors")
+	}
+	if !s.Internal() {
+		imports = append(imports, libuconf)
+	}
+	return s.execute(testTemplate, imports)
+}
+
+// execute runs t against s, and formats the result.
+func (s *spec) execute(t *template.Template, imports []string) ([]byte, error) {
+	sort.Strings(imports)
+	for i := 1; i < len(imports); i++ { // dedup
+		if imports[i] == imports[i-1] {
+			imports = append(imports[:i], imports[i+1:]...)
+			i--
+		}
+	}
+	var std, ext []string // grouped like goimports would
+	for _, v := range imports {
+		if strings.Contains(strings.SplitN(v, "/", 2)[0], ".") {
+			ext = append(ext, v)
+		} else {
+			std = append(std, v)
+		}
+	}
+
+	var b bytes.Buffer
+	err := t.Execute(&b, struct {
+		*spec
+		Std, Ext []string
+	}{s, std, ext})
+	if err != nil {
+		return nil, err
+	}
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, errors.New("generated invalid code: " + err.Error())
+	}
+	return out, nil
+}
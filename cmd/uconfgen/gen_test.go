@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+	convs, err := parseConvs("int64:int64ToPort,float32|float64:floatToPort")
+	assert.Nil(err)
+	s := &spec{
+		Name:    "Port",
+		Type:    "uint16",
+		Parse:   "parsePort",
+		Convs:   convs,
+		Package: "libuconf",
+	}
+	assert.Nil(s.check())
+	assert.Equal("a uint16", s.Doc)
+
+	// internal
+	out, err := s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "// PortOpt represents a uint16 Option.")
+	assert.Contains(string(out), "\tcase uint16:\n\t\tval = v\n")
+	assert.Contains(string(out), "\tcase float32, float64:\n\t\tval, err = floatToPort(v)\n")
+	assert.Contains(string(out), "\treturn env(r)\n")
+	assert.Contains(string(out), "func (o *OptionSet) PortVar(out *uint16,")
+	assert.NotContains(string(out), "libuconf.")
+	out, err = s.generateTest()
+	assert.Nil(err)
+	assert.Contains(string(out), "package libuconf_test\n")
+	assert.Contains(string(out), "\t. \"toast.cafe/x/libuconf\"\n")
+
+	// downstream
+	s.Package = "app"
+	out, err = s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "\t\"strings\"\n\n\t\"toast.cafe/x/libuconf\"\n")
+	assert.Contains(string(out), "_ libuconf.Setter  = (*PortOpt)(nil)")
+	assert.Contains(string(out), "func PortVar(o *libuconf.OptionSet, out *uint16,")
+	out, err = s.generateTest()
+	assert.Nil(err)
+	assert.Contains(string(out), "package app\n")
+	assert.Contains(string(out), "errors.Is(err, libuconf.ErrSet)")
+}
+
+func TestGenerateString(t *testing.T) {
+	assert := assert.New(t)
+	s := &spec{Name: "Host", Type: "string", Parse: "parseHost", Package: "libuconf"}
+	assert.Nil(s.check())
+	out, err := s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "\tcase string:\n\t\tval, err = parseHost(v)\n\tdefault:")
+}
+
+func TestGenerateDefault(t *testing.T) {
+	assert := assert.New(t)
+	convs, err := parseConvs("*:toName")
+	assert.Nil(err)
+	s := &spec{Name: "Name", Type: "string", Parse: "toName", Convs: convs, Package: "libuconf"}
+	assert.Nil(s.check())
+	assert.Equal("toName", s.Default)
+	assert.Empty(s.Convs)
+	out, err := s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "\tdefault:\n\t\tval, err = toName(vv)\n")
+	out, err = s.generateTest()
+	assert.Nil(err)
+	assert.NotContains(string(out), "struct{}{}")
+}
+
+func TestGenerateGet(t *testing.T) {
+	assert := assert.New(t)
+	s := &spec{Name: "Port", Type: "uint16", Parse: "p", Get: "showPort", Package: "libuconf"}
+	assert.Nil(s.check())
+	out, err := s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "func (r *PortOpt) Get() interface{} {\n\treturn showPort(*r.val)\n}")
+	out, err = s.generateTest()
+	assert.Nil(err)
+	assert.NotContains(string(out), "op.Get()")
+}
+
+func TestGenerateNegatable(t *testing.T) {
+	assert := assert.New(t)
+	s := &spec{Name: "Count", Type: "int", Parse: "p", Bool: true, Package: "libuconf"}
+	assert.Nil(s.check())
+	out, err := s.generate()
+	assert.Nil(err)
+	assert.NotContains(string(out), "Negatable")
+
+	s.NoNeg = true
+	out, err = s.generate()
+	assert.Nil(err)
+	assert.Contains(string(out), "_ NegatableOpt = (*CountOpt)(nil)")
+	assert.Contains(string(out), "func (*CountOpt) Negatable() bool {\n\treturn false\n}")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	assert := assert.New(t)
+	_, err := parseConvs("int64")
+	assert.NotNil(err)
+
+	for _, s := range []*spec{
+		{Name: "port", Type: "uint16", Parse: "p", Package: "p"},
+		{Name: "Port", Parse: "p", Package: "p"},
+		{Name: "Port", Type: "uint16", Package: "p"},
+		{Name: "Port", Type: "uint16", Parse: "p"},
+		{Name: "Port", Type: "uint16", Parse: "p", Package: "p",
+			Convs: []conversion{{[]string{"int", "uint16"}, "f"}}},
+		{Name: "Port", Type: "uint16", Parse: "p", Package: "p",
+			Convs: []conversion{{[]string{"int", "*"}, "f"}}},
+		{Name: "Port", Type: "uint16", Parse: "p", Package: "p",
+			Convs: []conversion{{[]string{"*"}, "f"}, {[]string{"*"}, "g"}}},
+		{Name: "Port", Type: "uint16", Parse: "p", Package: "p", NoNeg: true},
+	} {
+		assert.NotNil(s.check(), "%+v", s)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Uconfgen generates libuconf option types.
+
+Given a small description of a type, it writes the Opt type itself (with its
+interface compliance checks), its integration functions, and baseline tests.
+It is meant to be run through go generate, for example:
+
+	//go:generate go run toast.cafe/x/libuconf/cmd/uconfgen -type Port -go uint16 -parse parsePort -conv int64:int64ToPort
+
+This generates port_gen.go, containing PortOpt and NewPortOpt, along with
+port_gen_test.go.
+Strings (from flags, the environment, etc) are passed to the parse function,
+which must be a func(string) (T, error).
+Values that already are of the Go type are used as-is.
+Any other value (such as int64 or float64 from TOML) is passed to the matching
+conversion function, which must be a func(From) (T, error).
+Several types may share a function, as in "int32|int64:func", in which case it
+must be a func(interface{}) (T, error).
+The type "*" (as in "*:func") stands for any other type, which would otherwise
+be an error.
+Any error returned by these functions is wrapped in libuconf.ErrSet.
+
+Within libuconf itself, the integration functions are OptionSet methods
+(OptionSet.Port and OptionSet.PortVar).
+Elsewhere, they are package-level functions taking the OptionSet as their
+first argument (Port and PortVar), much like libuconf.Func.
+
+Usage:
+
+	uconfgen [flags]
+
+The flags are:
+
+	-type name
+		the name of the option, the type will be nameOpt (required)
+	-go type
+		the Go type the option holds (required)
+	-parse func
+		the function used to parse strings (required)
+	-conv from:func,...
+		the functions used to convert other types
+	-get func
+		the function Get passes the value through, a func(T) interface{}
+	-doc text
+		what the option is, as in "XOpt represents <text> Option."
+	-bool
+		the option is a boolean flag (see FlagOpt)
+	-negatable=false
+		a -bool option may not be given as --no-flag (see NegatableOpt)
+	-import path,...
+		extra imports needed by the Go type, such as "net" for net.IP
+	-valid string
+		a string the parse function accepts, to test with
+	-invalid string
+		a string the parse function rejects, to test with
+	-pkg name
+		the package to generate for (default $GOPACKAGE)
+	-o file
+		the output file (default <type>_gen.go)
+	-test=false
+		do not generate <type>_gen_test.go
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		s         spec
+		conv      string
+		imports   string
+		negatable bool
+		out       string
+		test      bool
+	)
+	flag.StringVar(&s.Name, "type", "", "the name of the option, the type will be nameOpt")
+	flag.StringVar(&s.Type, "go", "", "the Go type the option holds")
+	flag.StringVar(&s.Parse, "parse", "", "the function used to parse strings")
+	flag.StringVar(&conv, "conv", "", "the functions used to convert other types, as from:func,...")
+	flag.StringVar(&s.Get, "get", "", "the function Get passes the value through")
+	flag.StringVar(&s.Doc, "doc", "", `what the option is, as in "XOpt represents <doc> Option."`)
+	flag.BoolVar(&s.Bool, "bool", false, "the option is a boolean flag")
+	flag.BoolVar(&negatable, "negatable", true, "a -bool option may be given as --no-flag")
+	flag.StringVar(&imports, "import", "", "extra imports needed by the Go type, as path,...")
+	flag.StringVar(&s.Valid, "valid", "", "a string the parse function accepts, to test with")
+	flag.StringVar(&s.Invalid, "invalid", "", "a string the parse function rejects, to test with")
+	flag.StringVar(&s.Package, "pkg", os.Getenv("GOPACKAGE"), "the package to generate for")
+	flag.StringVar(&out, "o", "", "the output file (default <type>_gen.go)")
+	flag.BoolVar(&test, "test", true, "also generate <type>_gen_test.go")
+	flag.Parse()
+	s.NoNeg = !negatable
+
+	if err := run(&s, conv, imports, out, test); err != nil {
+		fmt.Fprintf(os.Stderr, "uconfgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run fills in the rest of s from the flags, and writes the files.
+func run(s *spec, conv, imports, out string, test bool) error {
+	var err error
+	if s.Convs, err = parseConvs(conv); err != nil {
+		return err
+	}
+	if imports != "" {
+		s.Imports = strings.Split(imports, ",")
+	}
+	if err = s.check(); err != nil {
+		return err
+	}
+
+	if out == "" {
+		out = strings.ToLower(s.Name) + "_gen.go"
+	}
+	src, err := s.generate()
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(out, src, 0644); err != nil {
+		return err
+	}
+	if !test {
+		return nil
+	}
+	if src, err = s.generateTest(); err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", src, 0644)
+}
+
+// parseConvs parses the -conv flag.
+func parseConvs(s string) ([]conversion, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var out []conversion
+	for _, v := range strings.Split(s, ",") {
+		kv := strings.SplitN(v, ":", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid conversion %q, want from:func", v)
+		}
+		out = append(out, conversion{strings.Split(kv[0], "|"), kv[1]})
+	}
+	return out, nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import "text/template"
+
+var optTemplate = template.Must(template.New("opt").Parse(`// Code generated by uconfgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Std}}
+	"{{.}}"
+{{- end}}
+{{- if .Ext}}
+{{range .Ext}}
+	"{{.}}"
+{{- end}}
+{{- end}}
+)
+
+// ensure interface compliance
+var (
+	_ {{.Q}}DirOpt  = (*{{.Name}}Opt)(nil)
+	_ {{.Q}}EnvOpt  = (*{{.Name}}Opt)(nil)
+	_ {{.Q}}FlagOpt = (*{{.Name}}Opt)(nil)
+	_ {{.Q}}Getter  = (*{{.Name}}Opt)(nil)
+{{- if .NoNeg}}
+	_ {{.Q}}NegatableOpt = (*{{.Name}}Opt)(nil)
+{{- end}}
+	_ {{.Q}}Setter  = (*{{.Name}}Opt)(nil)
+	_ {{.Q}}TomlOpt = (*{{.Name}}Opt)(nil)
+)
+
+// {{.Name}}Opt represents {{.Doc}} Option.
+type {{.Name}}Opt struct {
+	help  string
+	name  string
+	sname rune
+	val   *{{.Type}}
+}
+
+// New{{.Name}}Opt instantiates a {{.Name}}Opt and returns needed implementation details.
+func New{{.Name}}Opt(name string, sname rune, val {{.Type}}, help string) (*{{.Name}}Opt, *{{.Type}}) {
+	out := {{.Name}}Opt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *{{.Name}}Opt) File() string {
+{{- if .Internal}}
+	return _file(r)
+{{- else}}
+	return r.Flag()
+{{- end}}
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *{{.Name}}Opt) Env() string {
+{{- if .Internal}}
+	return env(r)
+{{- else}}
+	return strings.ToUpper(strings.ReplaceAll(r.Flag(), ".", "_"))
+{{- end}}
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*{{.Name}}Opt) Bool() bool {
+	return {{.Bool}}
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *{{.Name}}Opt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *{{.Name}}Opt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *{{.Name}}Opt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *{{.Name}}Opt) Get() interface{} {
+{{- if .Get}}
+	return {{.Get}}(*r.val)
+{{- else}}
+	return *r.val
+{{- end}}
+}
+{{- if .NoNeg}}
+
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-flag" is valid.
+//
+// This is always false for {{.Name}}Opt, even though Bool returns true.
+func (*{{.Name}}Opt) Negatable() bool {
+	return false
+}
+{{- end}}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *{{.Name}}Opt) Set(vv interface{}) error {
+	var (
+		val {{.Type}}
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = {{.Parse}}(v)
+{{- if not .StringType}}
+	case {{.Type}}:
+		val = v
+{{- end}}
+{{- range .Convs}}
+	case {{.Case}}:
+		val, err = {{.Func}}(v)
+{{- end}}
+	default:
+{{- if .Default}}
+		val, err = {{.Default}}(vv)
+{{- else}}
+		return fmt.Errorf("%w: to %+v", {{.Q}}ErrSet, vv)
+{{- end}}
+	}
+	if err != nil {
+{{- if .Internal}}
+		return errSetTo(vv, err)
+{{- else}}
+		return fmt.Errorf("%w: to %+v: %v", {{.Q}}ErrSet, vv, err)
+{{- end}}
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *{{.Name}}Opt) Toml() string {
+{{- if .Internal}}
+	return _toml(r)
+{{- else}}
+	return r.Flag()
+{{- end}}
+}
+
+// ---- integration
+{{if .Internal}}
+// {{.Name}} adds a {{.Name}}Opt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) {{.Name}}(name string, sname rune, val {{.Type}}, help string) *{{.Type}} {
+	op, v := New{{.Name}}Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// {{.Name}}Var adds a {{.Name}}Opt to the OptionSet.
+func (o *OptionSet) {{.Name}}Var(out *{{.Type}}, name string, sname rune, val {{.Type}}, help string) {
+	*out = val
+	op := &{{.Name}}Opt{help, name, sname, out}
+	o.Var(op)
+}
+{{- else}}
+// {{.Name}} adds a {{.Name}}Opt to the OptionSet.
+// It returns a pointer to the output value.
+func {{.Name}}(o *libuconf.OptionSet, name string, sname rune, val {{.Type}}, help string) *{{.Type}} {
+	op, v := New{{.Name}}Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// {{.Name}}Var adds a {{.Name}}Opt to the OptionSet.
+func {{.Name}}Var(o *libuconf.OptionSet, out *{{.Type}}, name string, sname rune, val {{.Type}}, help string) {
+	*out = val
+	op := &{{.Name}}Opt{help, name, sname, out}
+	o.Var(op)
+}
+{{- end}}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by uconfgen; DO NOT EDIT.
+
+package {{.Package}}{{if .Internal}}_test{{end}}
+
+import (
+{{- range .Std}}
+	"{{.}}"
+{{- end}}
+{{- if .Ext}}
+{{range .Ext}}
+	"{{.}}"
+{{- end}}
+{{- end}}
+{{- if .Internal}}
+
+	. "toast.cafe/x/libuconf"
+{{- end}}
+)
+
+func Test{{.Name}}Opt(t *testing.T) {
+	var val {{.Type}}
+	op, p := New{{.Name}}Opt("a.b-c", 'x', val, "help")
+	if op.Flag() != "a.b-c" || op.ShortFlag() != 'x' || op.Help() != "help" {
+		t.Errorf("got flags %q, %q and help %q", op.Flag(), op.ShortFlag(), op.Help())
+	}
+	if op.Env() != "A_B-C" || op.Toml() != "a.b-c" || op.File() != "a.b-c" {
+		t.Errorf("got env %q, toml %q and file %q", op.Env(), op.Toml(), op.File())
+	}
+	if op.Bool() != {{.Bool}} {
+		t.Errorf("got Bool() %v", op.Bool())
+	}
+{{- if .Get}}
+	if !reflect.DeepEqual(*p, val) {
+		t.Errorf("got %+v, want %+v", *p, val)
+	}
+{{- else}}
+	if !reflect.DeepEqual(op.Get(), val) || !reflect.DeepEqual(*p, val) {
+		t.Errorf("got %+v, want %+v", op.Get(), val)
+	}
+{{- end}}
+{{- if not .StringType}}
+	if err := op.Set(val); err != nil {
+		t.Errorf("setting to a {{.Type}}: %v", err)
+	}
+{{- end}}
+{{- if .Valid}}
+	if err := op.Set({{printf "%q" .Valid}}); err != nil {
+		t.Errorf("setting to %q: %v", {{printf "%q" .Valid}}, err)
+	}
+{{- end}}
+{{- if .Invalid}}
+	if err := op.Set({{printf "%q" .Invalid}}); !errors.Is(err, {{.Q}}ErrSet) {
+		t.Errorf("setting to %q: got %v, want ErrSet", {{printf "%q" .Invalid}}, err)
+	}
+{{- end}}
+{{- if not .Default}}
+	if err := op.Set(struct{}{}); !errors.Is(err, {{.Q}}ErrSet) {
+		t.Errorf("setting to a struct: got %v, want ErrSet", err)
+	}
+{{- end}}
+}
+
+func Test{{.Name}}Integration(t *testing.T) {
+	var (
+		o   = &{{.Q}}OptionSet{AppName: "test"}
+		val {{.Type}}
+		out {{.Type}}
+	)
+{{- if .Internal}}
+	p := o.{{.Name}}("a", 'a', val, "ahelp")
+	o.{{.Name}}Var(&out, "b", 'b', val, "bhelp")
+{{- else}}
+	p := {{.Name}}(o, "a", 'a', val, "ahelp")
+	{{.Name}}Var(o, &out, "b", 'b', val, "bhelp")
+{{- end}}
+	if !reflect.DeepEqual(*p, val) || !reflect.DeepEqual(out, val) {
+		t.Errorf("got %+v and %+v, want %+v", *p, out, val)
+	}
+	if o.FindLongFlag("a") == nil || o.FindShortFlag('b') == nil {
+		t.Error("options were not added to the OptionSet")
+	}
+}
+`))
@@ -0,0 +1,79 @@
+package libuconf
+
+import "path"
+
+/*
+ConfigPaths describes where the standard configuration files live.
+It is shared by every file format, which only provides the extension.
+
+Files are returned from least to most important, meaning later files take
+precedence over earlier ones.
+For a Name of "app" and an extension of "toml", the order is:
+
+	System/app.toml, System/app/config.toml (for each directory) ->
+	System/app/DropIn/*.toml (if DropIn is set)                   ->
+	Home/.apprc (if RC is true)                                   ->
+	User/app.toml, User/app/config.toml (for each directory)      ->
+	User/app/DropIn/*.toml (if DropIn is set)                     ->
+	Extra.toml (for each file)
+
+The rc file has no extension, and holds TOML, so other formats do not get it.
+
+Drop-in directories (such as /etc/app/conf.d) are returned as glob patterns.
+Their fragments are parsed in lexical order after the main files of the same
+directory, so later fragments override earlier ones.
+
+All of the fields may be freely modified.
+For example, to add a project-local ./.app.toml that overrides everything else:
+
+	o.Paths.Extra = append(o.Paths.Extra, "./.app")
+*/
+type ConfigPaths struct {
+	Name   string   // base name of the configuration files, usually AppName
+	System []string // system-wide directories, least important first
+	RC     bool     // whether or not to look for Home/.Namerc (TOML only)
+	Home   string   // directory containing the rc file; empty means none
+	User   []string // per-user directories, least important first
+	DropIn string   // name of drop-in directories (e.g "conf.d"); empty means none
+	Extra  []string // additional files (without extension), parsed last
+}
+
+// Files returns the list of files to consider for a given extension, in order.
+// The extension should not include the leading ".".
+func (c *ConfigPaths) Files(ext string) []string {
+	var (
+		file = c.Name + "." + ext
+		conf = path.Join(c.Name, "config."+ext)
+		out  []string
+	)
+	for _, d := range c.System {
+		out = append(out, c.dirFiles(d, file, conf, ext)...)
+	}
+	if c.RC && c.Home != "" && ext == "toml" {
+		out = append(out, path.Join(c.Home, "."+c.Name+"rc"))
+	}
+	for _, d := range c.User {
+		out = append(out, c.dirFiles(d, file, conf, ext)...)
+	}
+	for _, f := range c.Extra {
+		out = append(out, f+"."+ext)
+	}
+	return out
+}
+
+// dirFiles returns the files to consider within a single directory.
+func (c *ConfigPaths) dirFiles(d, file, conf, ext string) []string {
+	out := []string{path.Join(d, file), path.Join(d, conf)}
+	if c.DropIn != "" {
+		out = append(out, path.Join(d, c.Name, c.DropIn, "*."+ext))
+	}
+	return out
+}
+
+// configPaths returns OptionSet.Paths, or the standard ones if it's nil.
+func (o *OptionSet) configPaths() *ConfigPaths {
+	if o.Paths == nil {
+		return StdConfigPaths(o.AppName)
+	}
+	return o.Paths
+}
@@ -0,0 +1,74 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package libuconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+StdConfigPaths returns the standard ConfigPaths for a platform.
+
+It follows the XDG Base Directory specification:
+$XDG_CONFIG_DIRS (defaulting to /etc/xdg) is searched in reverse, so the most
+important directory is parsed last, followed by /etc.
+$XDG_CONFIG_HOME (defaulting to the OS user configuration directory) is the
+only user directory.
+The rc file is looked for in the user's home directory.
+Drop-in fragments are looked for in "conf.d" (e.g /etc/app/conf.d/*.toml).
+
+Directories that cannot be determined (for example, if $HOME is unset) are
+left out.
+*/
+func StdConfigPaths(name string) *ConfigPaths {
+	var (
+		dirs = xdgConfigDirs()
+		out  = &ConfigPaths{Name: name, RC: true, DropIn: "conf.d"}
+	)
+	for i := len(dirs) - 1; i >= 0; i-- { // least important first
+		out.System = append(out.System, dirs[i])
+	}
+	out.System = append(out.System, "/etc")
+	if uhome, err := os.UserHomeDir(); err == nil {
+		out.Home = uhome
+	}
+	if uconf, err := xdgConfigHome(); err == nil {
+		out.User = append(out.User, uconf)
+	}
+	return out
+}
+
+// xdgConfigHome returns $XDG_CONFIG_HOME, or the OS user config dir if unset.
+// Relative paths are invalid as per the specification, and thus ignored.
+func xdgConfigHome() (string, error) {
+	if v := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(v) {
+		return v, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil { // it may reject a relative $XDG_CONFIG_HOME itself
+		home, herr := os.UserHomeDir()
+		if herr != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".config"), nil
+	}
+	return dir, nil
+}
+
+// xdgConfigDirs returns $XDG_CONFIG_DIRS in order of importance.
+// Relative paths are invalid as per the specification, and thus ignored.
+func xdgConfigDirs() (out []string) {
+	v := os.Getenv("XDG_CONFIG_DIRS")
+	if v == "" {
+		v = "/etc/xdg"
+	}
+	for _, d := range strings.Split(v, ":") {
+		if filepath.IsAbs(d) {
+			out = append(out, d)
+		}
+	}
+	return
+}
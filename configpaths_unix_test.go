@@ -0,0 +1,44 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package libuconf_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdConfigPathsXDG(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("HOME", "/home/u")
+
+	// most important first in the variable, so last in System
+	t.Setenv("XDG_CONFIG_DIRS", "/a:/b")
+	t.Setenv("XDG_CONFIG_HOME", "/home/u/conf")
+	p := StdConfigPaths("app")
+	assert.Equal([]string{"/b", "/a", "/etc"}, p.System)
+	assert.Equal([]string{"/home/u/conf"}, p.User)
+	assert.Equal("/home/u", p.Home)
+
+	// empty variables fall back to the defaults
+	t.Setenv("XDG_CONFIG_DIRS", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	p = StdConfigPaths("app")
+	assert.Equal([]string{"/etc/xdg", "/etc"}, p.System)
+	if runtime.GOOS != "darwin" && runtime.GOOS != "ios" {
+		assert.Equal([]string{"/home/u/.config"}, p.User)
+	}
+
+	// relative paths are ignored
+	t.Setenv("XDG_CONFIG_DIRS", "rel:/a:./b")
+	t.Setenv("XDG_CONFIG_HOME", "conf")
+	p = StdConfigPaths("app")
+	assert.Equal([]string{"/a", "/etc"}, p.System)
+	if runtime.GOOS != "darwin" && runtime.GOOS != "ios" {
+		assert.Equal([]string{"/home/u/.config"}, p.User)
+	}
+}
@@ -0,0 +1,150 @@
+package libuconf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kinds of constraints
+const (
+	exclusive = iota
+	allOrNone
+	atLeastOne
+	requires
+)
+
+// constraint is a relationship between options, as declared on an OptionSet.
+// Options are referred to by their long flag.
+type constraint struct {
+	kind  int
+	flags []string // for requires, the first flag requires the rest
+}
+
+// Exclusive declares that at most one of the given options may be set.
+func (o *OptionSet) Exclusive(flags ...string) {
+	o.constraints = append(o.constraints, constraint{exclusive, flags})
+}
+
+// AllOrNone declares that if any of the given options is set, all must be.
+func (o *OptionSet) AllOrNone(flags ...string) {
+	o.constraints = append(o.constraints, constraint{allOrNone, flags})
+}
+
+// AtLeastOne declares that at least one of the given options must be set.
+func (o *OptionSet) AtLeastOne(flags ...string) {
+	o.constraints = append(o.constraints, constraint{atLeastOne, flags})
+}
+
+// Requires declares that if flag is set, all of deps must be set as well.
+func (o *OptionSet) Requires(flag string, deps ...string) {
+	o.constraints = append(o.constraints,
+		constraint{requires, append([]string{flag}, deps...)})
+}
+
+/*
+Check verifies the constraints declared with Exclusive, AllOrNone, AtLeastOne
+and Requires.
+Parse calls it once everything has been parsed, unless help was requested.
+
+An option counts as set if anything in the Parse family set it, even if it was
+set to its default value (see Source).
+If any constraints are violated, ErrConstraint is returned, naming the options
+involved and where each of them was set.
+If a constraint names an option that does not exist, ErrUnknownFlag is
+returned instead, as the constraint could never be violated.
+*/
+func (o *OptionSet) Check() error {
+	var names []string
+	o.VisitFlag(func(f FlagOpt) {
+		if f.Flag() != "" {
+			names = append(names, "--"+f.Flag())
+		}
+	})
+	for _, c := range o.constraints {
+		for _, v := range c.flags {
+			if !contains(names, "--"+v) {
+				return errUnknownFlag("--"+v,
+					didYouMean("--"+v, names)+" in constraint: "+c.String())
+			}
+		}
+	}
+
+	var errs []string
+	for _, c := range o.constraints {
+		if msg := c.check(o); msg != "" {
+			errs = append(errs, msg)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errConstraint(errs...)
+}
+
+// check returns a description of the violation, or an empty string.
+func (c constraint) check(o *OptionSet) string {
+	var set, unset []string
+	for _, v := range c.flags {
+		if src := o.flagSource(v); src != "" {
+			set = append(set, fmt.Sprintf("--%s (%s)", v, src))
+		} else {
+			unset = append(unset, "--"+v)
+		}
+	}
+
+	switch c.kind {
+	case exclusive:
+		if len(set) > 1 {
+			return join(set, "and") + " are mutually exclusive"
+		}
+	case allOrNone:
+		if len(set) > 0 && len(unset) > 0 {
+			return join(set, "and") + " must be set along with " + join(unset, "and")
+		}
+	case atLeastOne:
+		if len(set) == 0 {
+			return "one of " + join(unset, "or") + " is required"
+		}
+	case requires:
+		if o.flagSource(c.flags[0]) != "" && len(unset) > 0 {
+			return set[0] + " requires " + join(unset, "and")
+		}
+	}
+	return ""
+}
+
+// String describes the constraint, for Usage.
+func (c constraint) String() string {
+	flags := make([]string, len(c.flags))
+	for i, v := range c.flags {
+		flags[i] = "--" + v
+	}
+	switch c.kind {
+	case exclusive:
+		return join(flags, "and") + " are mutually exclusive"
+	case allOrNone:
+		return join(flags, "and") + " must be set together"
+	case atLeastOne:
+		return "one of " + join(flags, "or") + " is required"
+	default: // requires
+		return flags[0] + " requires " + join(flags[1:], "and")
+	}
+}
+
+// flagSource returns the Source of the option with the given long flag.
+func (o *OptionSet) flagSource(flag string) (src string) {
+	o.VisitFlag(func(f FlagOpt) {
+		if f.Flag() == flag {
+			src = o.Source(unalias(f))
+		}
+	})
+	return
+}
+
+// join joins ss in English, e.g "a, b and c".
+func join(ss []string, conj string) string {
+	if len(ss) < 2 {
+		return strings.Join(ss, "")
+	}
+	return strings.Join(ss[:len(ss)-1], ", ") + " " + conj + " " + ss[len(ss)-1]
+}
@@ -0,0 +1,67 @@
+package libuconf_test
+
+import (
+	"errors"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "constrainttest"}
+	)
+	o.Bool("json", 0, false, "jsonhelp")
+	o.Bool("yaml", 0, false, "yamlhelp")
+	o.String("tls-cert", 0, "", "tlscerthelp")
+	o.String("tls-key", 0, "", "tlskeyhelp")
+	o.String("user", 0, "", "userhelp")
+	o.String("password", 0, "", "passwordhelp")
+	o.Exclusive("json", "yaml")
+	o.Requires("tls-cert", "tls-key")
+	o.AllOrNone("user", "password")
+	o.AtLeastOne("json", "yaml")
+
+	err := o.Check()
+	assert.True(errors.Is(err, ErrConstraint))
+	assert.Equal("constraint violated: one of --json or --yaml is required", err.Error())
+
+	t.Setenv("CONSTRAINTTEST_YAML", "true")
+	assert.Nil(o.ParseEnv())
+	assert.Nil(o.ParseFlags([]string{"--json", "--tls-cert=x", "--password", "y"}))
+	assert.Equal("flag --json", o.Source(o.FindLongFlag("json")))
+	assert.Equal("env CONSTRAINTTEST_YAML", o.Source(o.FindLongFlag("yaml")))
+	assert.Equal("", o.Source(o.FindLongFlag("user")))
+
+	err = o.Check()
+	assert.True(errors.Is(err, ErrConstraint))
+	assert.Equal("constraint violated: "+
+		"--json (flag --json) and --yaml (env CONSTRAINTTEST_YAML) are mutually exclusive; "+
+		"--tls-cert (flag --tls-cert) requires --tls-key; "+
+		"--password (flag --password) must be set along with --user", err.Error())
+
+	// setting an option to false still counts as setting it
+	assert.Nil(o.ParseFlags([]string{"--no-json", "--tls-key=z", "--user", "u"}))
+	err = o.Check()
+	assert.Equal("constraint violated: "+
+		"--json (flag --no-json) and --yaml (env CONSTRAINTTEST_YAML) are mutually exclusive",
+		err.Error())
+}
+
+func TestCheckUnknown(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+	)
+	o.Bool("json", 0, false, "jsonhelp")
+	o.Bool("yaml", 0, false, "yamlhelp")
+	o.Exclusive("json", "yalm")
+
+	err := o.Check()
+	assert.True(errors.Is(err, ErrUnknownFlag))
+	assert.Equal("unknown flag: --yalm (did you mean --yaml?) "+
+		"in constraint: --json and --yalm are mutually exclusive", err.Error())
+}
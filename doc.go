@@ -16,7 +16,8 @@ OptionSet.Var(), which will make it available to the Parse family of functions.
 
 Built-In Systems
 
-Libuconf comes with three built-in systems for configuration handling.
+Libuconf comes with four built-in systems for configuration handling.
+DirOpt, used with ParseDir,
 EnvOpt, used with ParseEnv,
 FlagOpt, used with ParseFlags, and
 TomlOpt, used with ParseTomlFile.
@@ -29,10 +30,19 @@ Built-In Types
 
 Libuconf comes with several types that implement the needed interfaces for the
 built-in systems.
-These types are BoolOpt (a boolean option), FloatOpt (a float64 option),
+These types are BoolOpt (a boolean option),
+ByteSizeOpt (a size in bytes, such as "10MiB"),
+FloatOpt (a float64 option),
 HelpOpt (a special help option, only implementing FlagOpt),
-IntOpt (an int64 option), StringOpt (a string option) and
-UintOpt (a uint64 option).
+IntOpt (an int64 option), SecretOpt (a string option that is never displayed),
+StringOpt (a string option) and UintOpt (a uint64 option).
+There are also sized integer options: Int8Opt, Int16Opt, Int32Opt, Uint8Opt,
+Uint16Opt and Uint32Opt.
+Integer options reject values that do not fit in them (such as 300 for an
+Int8Opt, or 1.5 for any of them) with ErrSet, rather than truncating them.
+Finally, there are network options: AddrOpt (a host:port address),
+CIDROpt (a net.IPNet), IPOpt (a net.IP) and URLOpt (a url.URL, optionally
+restricted to some schemes).
 
 All of these built-in types have special integration functions within
 OptionSet.
@@ -42,6 +52,13 @@ It is entirely valid to create a BoolOpt (or any Opt) by hand, and call
 OptionSet.Var against it.
 In fact, this is what half of these integration functions do.
 
+For types that only need to be parsed from a string, FuncOpt implements all of
+the interfaces around a parse function of your choosing.
+Func and FuncVar are its integration functions.
+Alternatively, cmd/uconfgen generates a dedicated type (along with its
+integration functions and tests) from the same parse function, through
+go generate.
+
 Usage
 
 First, instantiate an OptionSet using NewOptionSet.
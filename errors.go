@@ -3,17 +3,141 @@ package libuconf
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Base errors that the package might return.
 var (
-	ErrNoVal = errors.New("missing value")
-	ErrSet   = errors.New("failed to set")
+	ErrAmbiguousFlag = errors.New("ambiguous flag")
+	ErrConstraint    = errors.New("constraint violated")
+	ErrDeprecated    = errors.New("deprecated flag")
+	ErrExtraArg      = errors.New("too many arguments")
+	ErrInclude       = errors.New("failed to include")
+	ErrNoVal         = errors.New("missing value")
+	ErrResponseFile  = errors.New("invalid response file")
+	ErrSet           = errors.New("failed to set")
+	ErrUnknownEnv    = errors.New("unknown environment variable")
+	ErrUnknownFlag   = errors.New("unknown flag")
+	ErrUnknownKey    = errors.New("unknown key")
 )
 
+func errAmbiguousFlag(flag string, candidates []string) error {
+	return fmt.Errorf("%w: --%s could be %s",
+		ErrAmbiguousFlag, flag, strings.Join(candidates, " or "))
+}
+func errConstraint(msgs ...string) error {
+	return fmt.Errorf("%w: %s", ErrConstraint, strings.Join(msgs, "; "))
+}
+func errDeprecated(flag, replacement string) error {
+	return fmt.Errorf("%w: %s, use %s instead", ErrDeprecated, flag, replacement)
+}
+func errExtraArg(args []string) error {
+	return fmt.Errorf("%w: %q", ErrExtraArg, args)
+}
+func errInclude(name string, err error) error {
+	if name != "" {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	return includeError{err}
+}
 func errNoVal(name string) error {
 	return fmt.Errorf("%w: %s", ErrNoVal, name)
 }
-func errSet(name, val string) error {
-	return fmt.Errorf("%w: %s to %s", ErrSet, name, val)
+func errResponse(name string, line int, err error) error {
+	return &responseError{name, line, err}
+}
+func errSet(opt Setter, name, val string, err error) error {
+	if f, ok := opt.(FlagOpt); ok {
+		opt = unalias(f)
+	}
+	if _, ok := opt.(secret); ok {
+		return &setError{name, redacted, nil}
+	}
+	if v, ok := opt.(redacter); ok {
+		val = v.redact(val)
+	}
+	// keep the reason given by Set, if any, without repeating ErrSet and val
+	var e *setError
+	if errors.As(err, &e) {
+		return &setError{name, val, e.err}
+	}
+	if err != nil && !errors.Is(err, ErrSet) { // e.g. reading a file failed
+		return &setError{name, val, err}
+	}
+	return &setError{name, val, nil}
+}
+func errSetTo(vv interface{}, err error) error {
+	return &setError{"", fmt.Sprintf("%+v", vv), err}
+}
+func errUnknownKey(keys ...string) error {
+	return fmt.Errorf("%w: %s", ErrUnknownKey, strings.Join(keys, "; "))
+}
+func errUnknownFlag(flag, suggestions string) error {
+	return fmt.Errorf("%w: %s%s", ErrUnknownFlag, flag, suggestions)
+}
+func errUnknownEnv(names ...string) error {
+	return fmt.Errorf("%w: %s", ErrUnknownEnv, strings.Join(names, "; "))
+}
+
+// includeError is both ErrInclude and the error that caused it.
+type includeError struct {
+	err error
+}
+
+func (e includeError) Error() string {
+	return ErrInclude.Error() + ": " + e.err.Error()
+}
+func (e includeError) Is(target error) bool {
+	return target == ErrInclude
+}
+func (e includeError) Unwrap() error {
+	return e.err
+}
+
+// responseError is ErrResponseFile, naming where in the file err happened.
+type responseError struct {
+	name string
+	line int // 0 if the error is about the whole file
+	err  error
+}
+
+func (e *responseError) Error() string {
+	msg := ErrResponseFile.Error() + ": " + e.name
+	if e.line != 0 {
+		msg += ":" + strconv.Itoa(e.line)
+	}
+	return msg + ": " + e.err.Error()
+}
+func (e *responseError) Is(target error) bool {
+	return target == ErrResponseFile
+}
+func (e *responseError) Unwrap() error {
+	return e.err
+}
+
+// setError is ErrSet, along with the error that caused it, if any.
+// Set returns it without a name, which errSet then fills in.
+type setError struct {
+	name string // how the option was given, such as a flag
+	val  string
+	err  error
+}
+
+func (e *setError) Error() string {
+	msg := ErrSet.Error() + ": "
+	if e.name != "" {
+		msg += e.name + " "
+	}
+	msg += "to " + e.val
+	if e.err != nil {
+		msg += ": " + e.err.Error()
+	}
+	return msg
+}
+func (e *setError) Is(target error) bool {
+	return target == ErrSet
+}
+func (e *setError) Unwrap() error {
+	return e.err
 }
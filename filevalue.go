@@ -0,0 +1,65 @@
+package libuconf
+
+import "strings"
+
+// FileValues allows the options with the given long flags to read their values
+// from files (see FileValueOpt).
+func (o *OptionSet) FileValues(flags ...string) {
+	if o.fileValues == nil {
+		o.fileValues = make(map[string]bool)
+	}
+	for _, v := range flags {
+		o.fileValues[v] = true
+	}
+}
+
+// fileValue reports whether opt may read its value from a file.
+func (o *OptionSet) fileValue(opt Setter) bool {
+	if v, ok := opt.(FileValueOpt); ok && v.FileValue() {
+		return true
+	}
+	if f, ok := opt.(FlagOpt); ok {
+		f = unalias(f)
+		if v, ok := f.(FileValueOpt); ok && v.FileValue() {
+			return true
+		}
+		return o.fileValues[f.Flag()]
+	}
+	return false
+}
+
+// fileRef returns the path named by val, if opt reads its value from there.
+func (o *OptionSet) fileRef(opt FlagOpt, val string) (string, bool) {
+	if len(val) > 1 && val[0] == '@' && o.fileValue(opt) {
+		return val[1:], true
+	}
+	return "", false
+}
+
+// setFlag sets opt to a value given on the command line, which came from src.
+// If the option allows it, "@path" sets it to the contents of path instead.
+func (o *OptionSet) setFlag(opt FlagOpt, val, src string) error {
+	if name, ok := o.fileRef(opt, val); ok {
+		return o.setFile(opt, name, src)
+	}
+	return o.set(opt, val, src)
+}
+
+// errFlag is errSet for a value given to setFlag.
+// A path to read the value from is not itself secret, so it isn't redacted.
+func (o *OptionSet) errFlag(opt FlagOpt, name, val string, err error) error {
+	if _, ok := o.fileRef(opt, val); ok {
+		return errSet(nil, name, val, err)
+	}
+	return errSet(opt, name, val, err)
+}
+
+// setFile sets opt to the contents of the file name, which came from src.
+func (o *OptionSet) setFile(opt Setter, name, src string) error {
+	b, err := o.readFile(name)
+	if err != nil {
+		return err
+	}
+	val := strings.TrimRight(string(b), "\r\n")
+	return o.set(opt, val, "file "+name+" ("+src+")")
+}
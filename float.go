@@ -1,108 +1,36 @@
 package libuconf
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 )
 
-// ensure interface compliance
-var (
-	_ EnvOpt  = (*FloatOpt)(nil)
-	_ FlagOpt = (*FloatOpt)(nil)
-	_ Getter  = (*FloatOpt)(nil)
-	_ Setter  = (*FloatOpt)(nil)
-	_ TomlOpt = (*FloatOpt)(nil)
-)
-
-// FloatOpt represents a long float Option.
-type FloatOpt struct {
-	help  string
-	name  string
-	sname rune
-	val   *float64
-}
-
-// NewFloatOpt instantiates a FloatOpt and returns needed implementation details.
-func NewFloatOpt(name string, sname rune, val float64, help string) (*FloatOpt, *float64) {
-	out := FloatOpt{help, name, sname, &val}
-	return &out, out.val
-}
-
-// ---- EnvOpt
-
-// Env returns the option's environment search string.
-// For example, if the app name is "APP" and Env() returns "FOO", we will look
-// for the environment variable "APP_FOO".
-func (r *FloatOpt) Env() string {
-	return env(r)
-}
-
-// ---- FlagOpt
-
-// Bool returns whether or not this option is a boolean.
-// This is important because ParseFlags() will handle them differently.
-func (*FloatOpt) Bool() bool {
-	return true
-}
-
-// Flag returns the long-form flag for this option.
-// All strings are valid, but non-printable ones aren't useful.
-func (r *FloatOpt) Flag() string {
-	return r.name
-}
+/*
+In this file we define the conversions used by FloatOpt, which is generated
+(see generate.go).
+*/
 
-// Help returns the help string for this option.
-// It is only used in the Usage() call.
-func (r *FloatOpt) Help() string {
-	return r.help
-}
-
-// ShortFlag returns the short-form flag for this option.
-// All runes are valid, but non-printable ones aren't useful.
-// 0 means "disabled".
-func (r *FloatOpt) ShortFlag() rune {
-	return r.sname
-}
-
-// ---- Getter
-
-// Get returns the internal value.
-// This is primarily used in Usage() to show the current value for options.
-func (r *FloatOpt) Get() interface{} {
-	return *r.val
-}
-
-// ---- Setter
-
-// Set sets this option's value.
-// It should be able to handle whatever type it might receive, which means it
-// must at least handle strings for being usable in ParseFlags.
-func (r *FloatOpt) Set(vv interface{}) error {
-	val := reflect.ValueOf(vv)
-	switch v := vv.(type) {
-	case string:
-		f, e := strconv.ParseFloat(v, 64)
-		if e != nil {
-			return fmt.Errorf("%w: to %+v", ErrSet, v)
-		}
-		*r.val = f
-	case int8, int16, int32, int64, int:
-		*r.val = float64(val.Int())
-	case uint8, uint16, uint32, uint64, uint:
-		*r.val = float64(val.Uint())
-	case float32, float64:
-		*r.val = val.Float() // WARNING: WILL TRUNCATE
-	default:
-		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+// parseFloat parses s as a float64.
+func parseFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Unwrap(err) // strip the strconv prefix
 	}
-	return nil
-}
-
-// ---- TomlOpt
-
-// Toml returns the option's config file search string.
-// It's passed as-is to toml.Tree.Get().
-func (r *FloatOpt) Toml() string {
-	return _toml(r)
+	return f, nil
+}
+
+// toFloat converts any integer or float vv to a float64.
+// Large integers and float32s may lose precision.
+func toFloat(vv interface{}) (float64, error) {
+	switch val := reflect.ValueOf(vv); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	}
+	return 0, fmt.Errorf("%T is not a number", vv)
 }
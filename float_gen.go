@@ -0,0 +1,146 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt       = (*FloatOpt)(nil)
+	_ EnvOpt       = (*FloatOpt)(nil)
+	_ FlagOpt      = (*FloatOpt)(nil)
+	_ Getter       = (*FloatOpt)(nil)
+	_ NegatableOpt = (*FloatOpt)(nil)
+	_ Setter       = (*FloatOpt)(nil)
+	_ TomlOpt      = (*FloatOpt)(nil)
+)
+
+// FloatOpt represents a long float Option.
+type FloatOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *float64
+}
+
+// NewFloatOpt instantiates a FloatOpt and returns needed implementation details.
+func NewFloatOpt(name string, sname rune, val float64, help string) (*FloatOpt, *float64) {
+	out := FloatOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *FloatOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *FloatOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*FloatOpt) Bool() bool {
+	return true
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *FloatOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *FloatOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *FloatOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *FloatOpt) Get() interface{} {
+	return *r.val
+}
+
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-flag" is valid.
+//
+// This is always false for FloatOpt, even though Bool returns true.
+func (*FloatOpt) Negatable() bool {
+	return false
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *FloatOpt) Set(vv interface{}) error {
+	var (
+		val float64
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseFloat(v)
+	case float64:
+		val = v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		val, err = toFloat(v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *FloatOpt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Float adds a FloatOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Float(name string, sname rune, val float64, help string) *float64 {
+	op, v := NewFloatOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// FloatVar adds a FloatOpt to the OptionSet.
+func (o *OptionSet) FloatVar(out *float64, name string, sname rune, val float64, help string) {
+	*out = val
+	op := &FloatOpt{help, name, sname, out}
+	o.Var(op)
+}
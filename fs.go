@@ -0,0 +1,76 @@
+package libuconf
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+/*
+fsName converts a path as used by the Parse family into a name valid for fs.FS.
+
+fs.FS names are unrooted and slash-separated, so absolute paths have their
+leading slash removed.
+For example, "/etc/app.toml" is looked up as "etc/app.toml".
+*/
+func fsName(name string) string {
+	name = strings.TrimLeft(path.Clean(name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// readFile reads a file from OptionSet.FS, or the OS filesystem if it's nil.
+func (o *OptionSet) readFile(name string) ([]byte, error) {
+	if o.FS == nil {
+		return os.ReadFile(name)
+	}
+	return fs.ReadFile(o.FS, fsName(name))
+}
+
+// glob returns the names of all files matching pattern, in lexical order.
+// Only the final element of pattern is a pattern, its directory is taken as-is.
+// Like readFile, it uses OptionSet.FS, or the OS filesystem if it's nil.
+// The returned names are rooted the same way the pattern is.
+func (o *OptionSet) glob(pattern string) ([]string, error) {
+	dir, file := path.Split(pattern)
+	pattern = escapeMeta(dir) + file
+	if o.FS == nil {
+		return filepath.Glob(pattern)
+	}
+	out, err := fs.Glob(o.FS, fsName(pattern))
+	if err != nil || !path.IsAbs(pattern) {
+		return out, err
+	}
+	for i, v := range out {
+		out[i] = "/" + v
+	}
+	return out, nil
+}
+
+// hasMeta reports whether the final element of a path contains any glob
+// metacharacters, making it a pattern for glob.
+func hasMeta(name string) bool {
+	return strings.ContainsAny(path.Base(name), `*?[\`)
+}
+
+// escapeMeta escapes the glob metacharacters in name, so it only matches itself.
+// Each is put in a character class, as filepath.Glob does not treat a backslash
+// as an escape on Windows.
+func escapeMeta(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch r {
+		case '\\':
+			b.WriteString(`[\\]`)
+		case '*', '?', '[':
+			b.WriteString("[" + string(r) + "]")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package libuconf
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*FuncOpt[int])(nil)
+	_ EnvOpt  = (*FuncOpt[int])(nil)
+	_ FlagOpt = (*FuncOpt[int])(nil)
+	_ Getter  = (*FuncOpt[int])(nil)
+	_ Setter  = (*FuncOpt[int])(nil)
+	_ TomlOpt = (*FuncOpt[int])(nil)
+)
+
+/*
+FuncOpt represents an Option of any type, built from a parse function.
+It makes custom types a one-liner, for example:
+
+	ip := libuconf.Func(o, "ip", 0, net.IPv4zero, "address to use",
+		func(s string) (net.IP, error) { ... })
+
+Strings (from flags, the environment, etc) are always passed to the parse
+function, and other values that already are of type T are used as-is.
+Anything else (such as TOML integers, which are int64) is passed to each of the
+converters in turn, and the first one to succeed wins.
+If T is bool, the option is treated as a boolean by ParseFlags.
+*/
+type FuncOpt[T any] struct {
+	help  string
+	name  string
+	sname rune
+	val   *T
+	parse func(string) (T, error)
+	conv  []func(interface{}) (T, error)
+}
+
+// NewFuncOpt instantiates a FuncOpt and returns needed implementation details.
+func NewFuncOpt[T any](name string, sname rune, val T, help string, parse func(string) (T, error), conv ...func(interface{}) (T, error)) (*FuncOpt[T], *T) {
+	out := FuncOpt[T]{help, name, sname, &val, parse, conv}
+	return &out, out.val
+}
+
+// Func adds a FuncOpt to the OptionSet.
+// It returns a pointer to the output value.
+//
+// This is not a method of OptionSet because methods cannot be generic.
+func Func[T any](o *OptionSet, name string, sname rune, val T, help string, parse func(string) (T, error), conv ...func(interface{}) (T, error)) *T {
+	op, v := NewFuncOpt(name, sname, val, help, parse, conv...)
+	o.Var(op)
+	return v
+}
+
+// FuncVar adds a FuncOpt to the OptionSet.
+func FuncVar[T any](o *OptionSet, out *T, name string, sname rune, val T, help string, parse func(string) (T, error), conv ...func(interface{}) (T, error)) {
+	*out = val
+	op := &FuncOpt[T]{help, name, sname, out, parse, conv}
+	o.Var(op)
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *FuncOpt[T]) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *FuncOpt[T]) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+//
+// It is true if T is bool.
+func (r *FuncOpt[T]) Bool() bool {
+	_, ok := interface{}(*r.val).(bool)
+	return ok
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *FuncOpt[T]) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *FuncOpt[T]) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *FuncOpt[T]) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *FuncOpt[T]) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *FuncOpt[T]) Set(vv interface{}) error {
+	switch v := vv.(type) {
+	case string: // first, in case T is a string type needing validation
+		t, e := r.parse(v)
+		if e != nil {
+			return errSetTo(v, e)
+		}
+		*r.val = t
+		return nil
+	case T:
+		*r.val = v
+		return nil
+	}
+	for _, f := range r.conv {
+		if t, e := f(vv); e == nil {
+			*r.val = t
+			return nil
+		}
+	}
+	return errSetTo(vv, nil)
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *FuncOpt[T]) Toml() string {
+	return _toml(r)
+}
@@ -0,0 +1,55 @@
+package libuconf_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncOpt(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		ip     = Func(o, "ip", 'i', net.IPv4zero, "iphelp",
+			func(s string) (net.IP, error) {
+				if ip := net.ParseIP(s); ip != nil {
+					return ip, nil
+				}
+				return nil, fmt.Errorf("invalid IP %q", s)
+			})
+		timeout = Func(o, "timeout", 0, time.Second, "timeouthelp",
+			time.ParseDuration,
+			func(vv interface{}) (time.Duration, error) {
+				if v, ok := vv.(int64); ok {
+					return time.Duration(v) * time.Second, nil
+				}
+				return 0, ErrSet
+			})
+		on bool
+	)
+	FuncVar(o, &on, "on", 0, false, "onhelp", func(s string) (bool, error) {
+		return s == "yes", nil
+	})
+
+	err := o.ParseFlags([]string{"-i", "127.0.0.1", "--timeout=5m", "--on"})
+	assert.Nil(err)
+	assert.Equal(net.ParseIP("127.0.0.1"), *ip)
+	assert.Equal(5*time.Minute, *timeout)
+	assert.True(on)
+
+	err = o.ParseTomlString("timeout = 30\non = false\n")
+	assert.Nil(err)
+	assert.Equal(30*time.Second, *timeout)
+	assert.False(on)
+
+	err = o.ParseFlags([]string{"--ip=nope"})
+	assert.True(errors.Is(err, ErrSet))
+	err = o.ParseTomlString("timeout = 1.5\n")
+	assert.True(errors.Is(err, ErrSet))
+}
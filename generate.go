@@ -0,0 +1,21 @@
+package libuconf
+
+/*
+In this file we generate the built-in Options that share most of their code.
+Each is created by cmd/uconfgen, see there for what the arguments mean.
+*/
+
+//go:generate go run ./cmd/uconfgen -type Bool -go bool -parse parseBool -bool -doc "a boolean" -valid TRUE -invalid yes
+//go:generate go run ./cmd/uconfgen -type Float -go float64 -parse parseFloat -conv int|int8|int16|int32|int64|uint|uint8|uint16|uint32|uint64|float32:toFloat -bool -negatable=false -doc "a long float" -valid 1.5 -invalid 1.5.0
+//go:generate go run ./cmd/uconfgen -type Int -go int64 -parse parseInt[int64] -conv int|int8|int16|int32|uint|uint8|uint16|uint32|uint64|float32|float64:toInt[int64] -bool -negatable=false -doc "a 64-bit integer" -valid -9223372036854775808 -invalid 9223372036854775808
+//go:generate go run ./cmd/uconfgen -type String -go string -parse toString -conv *:toString -doc "a string" -valid anything
+//go:generate go run ./cmd/uconfgen -type Uint -go uint64 -parse parseUint[uint64] -conv int|int8|int16|int32|int64|uint|uint8|uint16|uint32|float32|float64:toUint[uint64] -bool -negatable=false -doc "an unsigned 64-bit integer" -valid 18446744073709551615 -invalid -1
+//go:generate go run ./cmd/uconfgen -type Int8 -go int8 -parse parseInt[int8] -conv int|int16|int32|int64|uint|uint8|uint16|uint32|uint64|float32|float64:toInt[int8] -doc "an 8-bit integer" -valid -128 -invalid 128
+//go:generate go run ./cmd/uconfgen -type Int16 -go int16 -parse parseInt[int16] -conv int|int8|int32|int64|uint|uint8|uint16|uint32|uint64|float32|float64:toInt[int16] -doc "a 16-bit integer" -valid -32768 -invalid 32768
+//go:generate go run ./cmd/uconfgen -type Int32 -go int32 -parse parseInt[int32] -conv int|int8|int16|int64|uint|uint8|uint16|uint32|uint64|float32|float64:toInt[int32] -doc "a 32-bit integer" -valid -2147483648 -invalid 2147483648
+//go:generate go run ./cmd/uconfgen -type Uint8 -go uint8 -parse parseUint[uint8] -conv int|int8|int16|int32|int64|uint|uint16|uint32|uint64|float32|float64:toUint[uint8] -doc "an unsigned 8-bit integer" -valid 255 -invalid 256
+//go:generate go run ./cmd/uconfgen -type Uint16 -go uint16 -parse parseUint[uint16] -conv int|int8|int16|int32|int64|uint|uint8|uint32|uint64|float32|float64:toUint[uint16] -doc "an unsigned 16-bit integer" -valid 65535 -invalid 65536
+//go:generate go run ./cmd/uconfgen -type Uint32 -go uint32 -parse parseUint[uint32] -conv int|int8|int16|int32|int64|uint|uint8|uint16|uint64|float32|float64:toUint[uint32] -doc "an unsigned 32-bit integer" -valid 4294967295 -invalid 4294967296
+//go:generate go run ./cmd/uconfgen -type ByteSize -go ByteSize -parse parseByteSize -conv int|int8|int16|int32|int64|uint|uint8|uint16|uint32|uint64|float32|float64:toUint[ByteSize] -doc "a ByteSize" -valid 10MiB -invalid 10XB
+//go:generate go run ./cmd/uconfgen -type Addr -go string -parse parseAddr -doc "a host:port address" -valid localhost:8080 -invalid localhost:65536
+//go:generate go run ./cmd/uconfgen -type IP -go net.IP -parse parseIP -get showIP -import net -doc "an IP address" -valid ::1 -invalid 256.0.0.1
@@ -1,8 +1,14 @@
 module toast.cafe/x/libuconf
 
-go 1.14
+go 1.18
 
 require (
 	github.com/pelletier/go-toml v1.7.0
 	github.com/stretchr/testify v1.5.1
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v2 v2.2.8 // indirect
+)
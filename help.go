@@ -4,8 +4,13 @@ import "fmt"
 
 // ensure interface compliance
 var (
-	_ FlagOpt = (*HelpOpt)(nil)
-	_ Setter  = (*HelpOpt)(nil)
+	_ FlagOpt      = (*HelpOpt)(nil)
+	_ NegatableOpt = (*HelpOpt)(nil)
+	_ Setter       = (*HelpOpt)(nil)
+
+	_ FlagOpt      = (*HelpAllOpt)(nil)
+	_ NegatableOpt = (*HelpAllOpt)(nil)
+	_ Setter       = (*HelpAllOpt)(nil)
 )
 
 // HelpOpt is a special Option to provide -h and --help.
@@ -46,6 +51,15 @@ func (r *HelpOpt) ShortFlag() rune {
 	return 'h'
 }
 
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-help" is valid.
+//
+// This is always false for HelpOpt.
+func (*HelpOpt) Negatable() bool {
+	return false
+}
+
 // ---- Setter
 
 // Set sets this option's value.
@@ -62,3 +76,67 @@ func (r *HelpOpt) Set(vv interface{}) error {
 	}
 	return fmt.Errorf("%w: help to %+v", ErrSet, vv)
 }
+
+// HelpAllOpt is a special Option to provide --help-all.
+// Unlike HelpOpt, it makes Parse call UsageAll, which includes hidden options.
+type HelpAllOpt bool
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+//
+// This is always true for HelpAllOpt.
+func (*HelpAllOpt) Bool() bool {
+	return true
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+//
+// This is always "help-all" for HelpAllOpt.
+func (r *HelpAllOpt) Flag() string {
+	return "help-all"
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+//
+// It is always "view this help message, including hidden options" for
+// HelpAllOpt.
+func (r *HelpAllOpt) Help() string {
+	return "view this help message, including hidden options"
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+//
+// This is always 0 for HelpAllOpt.
+func (r *HelpAllOpt) ShortFlag() rune {
+	return 0
+}
+
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-help-all" is valid.
+//
+// This is always false for HelpAllOpt.
+func (*HelpAllOpt) Negatable() bool {
+	return false
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+//
+// Like HelpOpt, it only consumes boolean options.
+func (r *HelpAllOpt) Set(vv interface{}) error {
+	if v, ok := vv.(bool); ok {
+		*r = HelpAllOpt(v)
+		return nil
+	}
+	return fmt.Errorf("%w: help-all to %+v", ErrSet, vv)
+}
@@ -0,0 +1,136 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*Int16Opt)(nil)
+	_ EnvOpt  = (*Int16Opt)(nil)
+	_ FlagOpt = (*Int16Opt)(nil)
+	_ Getter  = (*Int16Opt)(nil)
+	_ Setter  = (*Int16Opt)(nil)
+	_ TomlOpt = (*Int16Opt)(nil)
+)
+
+// Int16Opt represents a 16-bit integer Option.
+type Int16Opt struct {
+	help  string
+	name  string
+	sname rune
+	val   *int16
+}
+
+// NewInt16Opt instantiates a Int16Opt and returns needed implementation details.
+func NewInt16Opt(name string, sname rune, val int16, help string) (*Int16Opt, *int16) {
+	out := Int16Opt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *Int16Opt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *Int16Opt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*Int16Opt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *Int16Opt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *Int16Opt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *Int16Opt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *Int16Opt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *Int16Opt) Set(vv interface{}) error {
+	var (
+		val int16
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseInt[int16](v)
+	case int16:
+		val = v
+	case int, int8, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		val, err = toInt[int16](v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *Int16Opt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Int16 adds a Int16Opt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Int16(name string, sname rune, val int16, help string) *int16 {
+	op, v := NewInt16Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// Int16Var adds a Int16Opt to the OptionSet.
+func (o *OptionSet) Int16Var(out *int16, name string, sname rune, val int16, help string) {
+	*out = val
+	op := &Int16Opt{help, name, sname, out}
+	o.Var(op)
+}
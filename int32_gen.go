@@ -0,0 +1,136 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*Int32Opt)(nil)
+	_ EnvOpt  = (*Int32Opt)(nil)
+	_ FlagOpt = (*Int32Opt)(nil)
+	_ Getter  = (*Int32Opt)(nil)
+	_ Setter  = (*Int32Opt)(nil)
+	_ TomlOpt = (*Int32Opt)(nil)
+)
+
+// Int32Opt represents a 32-bit integer Option.
+type Int32Opt struct {
+	help  string
+	name  string
+	sname rune
+	val   *int32
+}
+
+// NewInt32Opt instantiates a Int32Opt and returns needed implementation details.
+func NewInt32Opt(name string, sname rune, val int32, help string) (*Int32Opt, *int32) {
+	out := Int32Opt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *Int32Opt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *Int32Opt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*Int32Opt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *Int32Opt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *Int32Opt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *Int32Opt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *Int32Opt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *Int32Opt) Set(vv interface{}) error {
+	var (
+		val int32
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseInt[int32](v)
+	case int32:
+		val = v
+	case int, int8, int16, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		val, err = toInt[int32](v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *Int32Opt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Int32 adds a Int32Opt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Int32(name string, sname rune, val int32, help string) *int32 {
+	op, v := NewInt32Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// Int32Var adds a Int32Opt to the OptionSet.
+func (o *OptionSet) Int32Var(out *int32, name string, sname rune, val int32, help string) {
+	*out = val
+	op := &Int32Opt{help, name, sname, out}
+	o.Var(op)
+}
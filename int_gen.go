@@ -1,18 +1,20 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
 package libuconf
 
 import (
 	"fmt"
-	"reflect"
-	"strconv"
 )
 
 // ensure interface compliance
 var (
-	_ EnvOpt  = (*IntOpt)(nil)
-	_ FlagOpt = (*IntOpt)(nil)
-	_ Getter  = (*IntOpt)(nil)
-	_ Setter  = (*IntOpt)(nil)
-	_ TomlOpt = (*IntOpt)(nil)
+	_ DirOpt       = (*IntOpt)(nil)
+	_ EnvOpt       = (*IntOpt)(nil)
+	_ FlagOpt      = (*IntOpt)(nil)
+	_ Getter       = (*IntOpt)(nil)
+	_ NegatableOpt = (*IntOpt)(nil)
+	_ Setter       = (*IntOpt)(nil)
+	_ TomlOpt      = (*IntOpt)(nil)
 )
 
 // IntOpt represents a 64-bit integer Option.
@@ -29,6 +31,15 @@ func NewIntOpt(name string, sname rune, val int64, help string) (*IntOpt, *int64
 	return &out, out.val
 }
 
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *IntOpt) File() string {
+	return _file(r)
+}
+
 // ---- EnvOpt
 
 // Env returns the option's environment search string.
@@ -73,29 +84,39 @@ func (r *IntOpt) Get() interface{} {
 	return *r.val
 }
 
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-flag" is valid.
+//
+// This is always false for IntOpt, even though Bool returns true.
+func (*IntOpt) Negatable() bool {
+	return false
+}
+
 // ---- Setter
 
 // Set sets this option's value.
 // It should be able to handle whatever type it might receive, which means it
 // must at least handle strings for being usable in ParseFlags.
 func (r *IntOpt) Set(vv interface{}) error {
-	val := reflect.ValueOf(vv)
+	var (
+		val int64
+		err error
+	)
 	switch v := vv.(type) {
 	case string:
-		i, e := strconv.ParseInt(v, 0, 0)
-		if e != nil {
-			return fmt.Errorf("%w: to %+v", ErrSet, v)
-		}
-		*r.val = i
-	case int8, int16, int32, int64, int:
-		*r.val = val.Int()
-	case uint8, uint16, uint32, uint64, uint:
-		*r.val = int64(val.Uint()) // WARNING: MAY TRUNCATE
-	case float32, float64:
-		*r.val = int64(val.Float()) // WARNING: WILL TRUNCATE
+		val, err = parseInt[int64](v)
+	case int64:
+		val = v
+	case int, int8, int16, int32, uint, uint8, uint16, uint32, uint64, float32, float64:
+		val, err = toInt[int64](v)
 	default:
 		return fmt.Errorf("%w: to %+v", ErrSet, vv)
 	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
 	return nil
 }
 
@@ -106,3 +127,20 @@ func (r *IntOpt) Set(vv interface{}) error {
 func (r *IntOpt) Toml() string {
 	return _toml(r)
 }
+
+// ---- integration
+
+// Int adds a IntOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Int(name string, sname rune, val int64, help string) *int64 {
+	op, v := NewIntOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// IntVar adds a IntOpt to the OptionSet.
+func (o *OptionSet) IntVar(out *int64, name string, sname rune, val int64, help string) {
+	*out = val
+	op := &IntOpt{help, name, sname, out}
+	o.Var(op)
+}
@@ -0,0 +1,121 @@
+package libuconf
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+/*
+In this file we define the conversions shared by the integer Options.
+Every conversion is range checked: values that do not fit in the target type,
+or floats that are not whole numbers, are errors rather than being truncated.
+*/
+
+type signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// errRange describes the range of T, for values that do not fit in it.
+func errRange[T signed | unsigned](v interface{}) error {
+	var (
+		t    T
+		typ  = reflect.TypeOf(t)
+		bits = typ.Bits()
+	)
+	if typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uint64 {
+		return fmt.Errorf("%v is out of range for %s [0, %d]",
+			v, typ, uint64(math.MaxUint64)>>(64-bits))
+	}
+	return fmt.Errorf("%v is out of range for %s [%d, %d]",
+		v, typ, int64(math.MinInt64)>>(64-bits), int64(math.MaxInt64)>>(64-bits))
+}
+
+// parseInt parses s (in any base strconv accepts with base 0) into T.
+func parseInt[T signed](s string) (T, error) {
+	i, err := strconv.ParseInt(s, 0, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, errRange[T](s)
+	} else if err != nil {
+		return 0, errors.Unwrap(err) // strip the strconv prefix
+	}
+	return toInt[T](i)
+}
+
+// parseUint parses s (in any base strconv accepts with base 0) into T.
+func parseUint[T unsigned](s string) (T, error) {
+	i, err := strconv.ParseUint(s, 0, 64)
+	if _, e := strconv.ParseInt(s, 0, 64); err != nil && e == nil { // negative
+		return 0, errRange[T](s)
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, errRange[T](s)
+	} else if err != nil {
+		return 0, errors.Unwrap(err)
+	}
+	return toUint[T](i)
+}
+
+// toInt converts any integer or float vv to T.
+func toInt[T signed](vv interface{}) (T, error) {
+	var i int64
+	switch val := reflect.ValueOf(vv); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = val.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > math.MaxInt64 {
+			return 0, errRange[T](vv)
+		}
+		i = int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("%v is not a whole number", vv)
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 { // MaxInt64 rounds up
+			return 0, errRange[T](vv)
+		}
+		i = int64(f)
+	default:
+		return 0, fmt.Errorf("%T is not a number", vv)
+	}
+	if t := T(i); int64(t) == i {
+		return t, nil
+	}
+	return 0, errRange[T](vv)
+}
+
+// toUint converts any integer or float vv to T.
+func toUint[T unsigned](vv interface{}) (T, error) {
+	var u uint64
+	switch val := reflect.ValueOf(vv); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Int() < 0 {
+			return 0, errRange[T](vv)
+		}
+		u = uint64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u = val.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("%v is not a whole number", vv)
+		}
+		if f < 0 || f >= math.MaxUint64 { // MaxUint64 rounds up
+			return 0, errRange[T](vv)
+		}
+		u = uint64(f)
+	default:
+		return 0, fmt.Errorf("%T is not a number", vv)
+	}
+	if t := T(u); uint64(t) == u {
+		return t, nil
+	}
+	return 0, errRange[T](vv)
+}
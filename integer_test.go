@@ -0,0 +1,103 @@
+package libuconf_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizedInts(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		i8     = o.Int8("i8", 'i', 0, "i8help")
+		i32    = o.Int32("i32", 0, 0, "i32help")
+		u8     = o.Uint8("u8", 'u', 0, "u8help")
+		i16    int16
+	)
+	o.Int16Var(&i16, "i16", 0, 5, "i16help")
+	assert.Equal(int16(5), i16)
+
+	err := o.ParseFlags([]string{"-i", "-128", "--i32=0x7fffffff", "-u255"})
+	assert.Nil(err)
+	assert.Equal(int8(-128), *i8)
+	assert.Equal(int32(1<<31-1), *i32)
+	assert.Equal(uint8(255), *u8)
+
+	err = o.ParseTomlString("i8 = 127\ni16 = -32768\nu8 = 1.0\n")
+	assert.Nil(err)
+	assert.Equal(int8(127), *i8)
+	assert.Equal(int16(-32768), i16)
+	assert.Equal(uint8(1), *u8)
+
+	for _, v := range []struct {
+		args []string
+		msg  string
+	}{
+		{[]string{"--i8=128"}, "failed to set: i8 to 128: 128 is out of range for int8 [-128, 127]"},
+		{[]string{"-u", "-1"}, "failed to set: u to -1: -1 is out of range for uint8 [0, 255]"},
+		{[]string{"--i32=2147483648"}, "failed to set: i32 to 2147483648: 2147483648 is out of range for int32 [-2147483648, 2147483647]"},
+	} {
+		err = o.ParseFlags(v.args)
+		assert.True(errors.Is(err, ErrSet), "%v", v.args)
+		assert.EqualError(err, v.msg)
+		assert.Contains(v.msg, ": "+fmt.Sprint(errors.Unwrap(err))) // the reason
+	}
+
+	for _, v := range []struct {
+		toml string
+		msg  string
+	}{
+		{"i8 = 300", "failed to set: to 300: 300 is out of range for int8 [-128, 127]"},
+		{"u8 = -1", "failed to set: to -1: -1 is out of range for uint8 [0, 255]"},
+		{"u8 = 2.5", "failed to set: to 2.5: 2.5 is not a whole number"},
+		{"i16 = 1e10", "failed to set: to 1e+10: 1e+10 is out of range for int16 [-32768, 32767]"},
+	} {
+		err = o.ParseTomlString(v.toml)
+		assert.True(errors.Is(err, ErrSet), v.toml)
+		assert.EqualError(err, v.msg)
+	}
+	// nothing was truncated
+	assert.Equal(int8(127), *i8)
+	assert.Equal(uint8(1), *u8)
+	assert.Equal(int16(-32768), i16)
+}
+
+func TestIntOverflow(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		i      = o.Int("int", 0, 0, "inthelp")
+		u      = o.Uint("uint", 0, 0, "uinthelp")
+	)
+	assert.Nil(o.ParseTomlString("int = -5\nuint = 5.0\n"))
+	assert.Equal(int64(-5), *i)
+	assert.Equal(uint64(5), *u)
+
+	for _, v := range []struct {
+		toml string
+		msg  string
+	}{
+		{"uint = -5", "failed to set: to -5: -5 is out of range for uint64 [0, 18446744073709551615]"},
+		{"int = 1.5", "failed to set: to 1.5: 1.5 is not a whole number"},
+		{"int = 1e19", "failed to set: to 1e+19: 1e+19 is out of range for int64 [-9223372036854775808, 9223372036854775807]"},
+	} {
+		err := o.ParseTomlString(v.toml)
+		assert.True(errors.Is(err, ErrSet), v.toml)
+		assert.EqualError(err, v.msg)
+	}
+	assert.EqualError(o.ParseFlags([]string{"--uint=99999999999999999999"}),
+		"failed to set: uint to 99999999999999999999: 99999999999999999999 is out of range for uint64 [0, 18446744073709551615]")
+	assert.EqualError(o.ParseFlags([]string{"--int=five"}),
+		"failed to set: int to five: invalid syntax")
+
+	// uint64 values beyond int64 do not wrap around
+	iop, _ := NewIntOpt("int", 0, 0, "")
+	err := iop.Set(uint64(1 << 63))
+	assert.EqualError(err, "failed to set: to 9223372036854775808: 9223372036854775808 is out of range for int64 [-9223372036854775808, 9223372036854775807]")
+	assert.Equal(int64(-5), *i)
+}
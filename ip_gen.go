@@ -0,0 +1,135 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+	"net"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*IPOpt)(nil)
+	_ EnvOpt  = (*IPOpt)(nil)
+	_ FlagOpt = (*IPOpt)(nil)
+	_ Getter  = (*IPOpt)(nil)
+	_ Setter  = (*IPOpt)(nil)
+	_ TomlOpt = (*IPOpt)(nil)
+)
+
+// IPOpt represents an IP address Option.
+type IPOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *net.IP
+}
+
+// NewIPOpt instantiates a IPOpt and returns needed implementation details.
+func NewIPOpt(name string, sname rune, val net.IP, help string) (*IPOpt, *net.IP) {
+	out := IPOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *IPOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *IPOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*IPOpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *IPOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *IPOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *IPOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *IPOpt) Get() interface{} {
+	return showIP(*r.val)
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *IPOpt) Set(vv interface{}) error {
+	var (
+		val net.IP
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseIP(v)
+	case net.IP:
+		val = v
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *IPOpt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// IP adds a IPOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) IP(name string, sname rune, val net.IP, help string) *net.IP {
+	op, v := NewIPOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// IPVar adds a IPOpt to the OptionSet.
+func (o *OptionSet) IPVar(out *net.IP, name string, sname rune, val net.IP, help string) {
+	*out = val
+	op := &IPOpt{help, name, sname, out}
+	o.Var(op)
+}
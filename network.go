@@ -0,0 +1,43 @@
+package libuconf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+/*
+In this file we define the parsing shared by the network Options.
+AddrOpt and IPOpt are generated (see generate.go), while CIDROpt and URLOpt
+hold types that are best shown through a pointer, and are in their own files.
+*/
+
+// parseAddr checks that s is a host:port address, with a numeric port.
+// The host may be empty (as in ":8080"), which listens on all addresses.
+func parseAddr(s string) (string, error) {
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", err
+	}
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("port %q must be a number from 0 to 65535", port)
+	}
+	return s, nil
+}
+
+// parseIP parses s as an IPv4 or IPv6 address.
+func parseIP(s string) (net.IP, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		return ip, nil
+	}
+	return nil, errors.New("invalid IP address")
+}
+
+// showIP is what IPOpt.Get returns: the address, or "" if there is none.
+func showIP(ip net.IP) interface{} {
+	if ip == nil {
+		return ""
+	}
+	return ip
+}
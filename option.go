@@ -2,6 +2,19 @@ package libuconf
 
 import "strings"
 
+/*
+DirOpt describes an option that can be set from a file within a directory.
+
+It is used by ParseDir() to determine what files to read.
+For example, if File() returns "db.host", ParseDir("/dir") will set the option
+to the contents of "/dir/db.host".
+This matches the layout of Kubernetes ConfigMaps and systemd credentials.
+*/
+type DirOpt interface {
+	Setter
+	File() string // example: a.b.c
+}
+
 /*
 EnvOpt describes an option that can be set from the environment.
 
@@ -14,6 +27,22 @@ type EnvOpt interface {
 	Env() string // example: A_B_C
 }
 
+/*
+FileValueOpt describes an option that can choose whether or not its value may
+be read from a file, which is useful for secrets.
+
+If FileValue returns true, ParseEnv will also look for APP_FOO_FILE (where
+Env() returns "FOO"), and set the option to the contents of the file it names.
+Likewise, ParseFlags will treat a value of "@path" as the contents of path.
+Trailing newlines are removed from the contents.
+Options that cannot implement FileValueOpt can opt in using
+OptionSet.FileValues.
+*/
+type FileValueOpt interface {
+	Setter
+	FileValue() bool
+}
+
 /*
 FlagOpt describes an option that can be set using a flag.
 It is used by ParseFlags.
@@ -35,6 +64,32 @@ type FlagOpt interface {
 	ShortFlag() rune // can be 0: means disabled; example: x
 }
 
+/*
+HiddenOpt describes a FlagOpt that can choose whether or not it is hidden.
+
+Hidden options are left out of Usage (but not UsageAll), and should be left out
+of anything else describing the available options, such as completions.
+They are still parsed like any other option.
+Options that cannot implement HiddenOpt can be hidden using OptionSet.Hide.
+*/
+type HiddenOpt interface {
+	FlagOpt
+	Hidden() bool
+}
+
+/*
+NegatableOpt describes a FlagOpt that can choose whether or not it is negatable.
+
+By default, a FlagOpt whose Bool returns true may also be given as "--no-flag",
+in which case ParseFlags will pass false (bool) to Set(), and Usage will show it
+as "--[no-]flag".
+Options that do not want this should implement NegatableOpt, returning false.
+*/
+type NegatableOpt interface {
+	FlagOpt
+	Negatable() bool
+}
+
 /*
 Getter describes an option that can return its *current* value.
 
@@ -75,6 +130,10 @@ type TomlOpt interface {
 	Toml() string // a TOML query string; example: a.b.c
 }
 
+func _file(f FlagOpt) string {
+	return f.Flag()
+}
+
 func env(f FlagOpt) string {
 	return strings.ToUpper(strings.ReplaceAll(f.Flag(), ".", "_"))
 }
@@ -82,3 +141,11 @@ func env(f FlagOpt) string {
 func _toml(f FlagOpt) string {
 	return f.Flag()
 }
+
+// negatable reports whether f may be given as "--no-flag".
+func negatable(f FlagOpt) bool {
+	if v, ok := f.(NegatableOpt); ok && !v.Negatable() {
+		return false
+	}
+	return f.Bool()
+}
@@ -2,6 +2,8 @@ package libuconf
 
 import (
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"strings"
 )
@@ -11,21 +13,62 @@ import (
 // llen will be == 0 if output isn't a tty
 const minHL = 5
 
-// OptionSet represents a set of options.
+/*
+OptionSet represents a set of options.
+
+FS is the filesystem used by every file-reading parser (such as ParseTomlFile
+and ParseStdToml).
+If it is nil, the OS filesystem is used.
+Otherwise, paths are resolved against it with any leading "/" removed, which
+allows configuration to come from an embed.FS or a testing/fstest.MapFS.
+
+Paths describes where standard configuration files are looked for (see
+ConfigPaths).
+If it is nil, StdConfigPaths(AppName) is used.
+
+AbbrevFlags allows long flags to be abbreviated, as long as the abbreviation
+is unambiguous (see LookupLongFlag).
+POSIX makes ParseFlags stop at the first argument that isn't a flag.
+ResponseFiles makes ParseFlags expand "@path" arguments (see ParseFlags).
+
+StrictEnv, StrictFlags and StrictToml decide what happens to environment
+variables, flags and TOML keys that do not correspond to any option (see
+Strictness).
+Warnings are passed to Warn, or printed if it is nil.
+
+Output is where Usage (and warnings) are printed.
+If it is nil, os.Stderr is used.
+*/
 type OptionSet struct {
-	AppName string
-	options []Setter // least common denominator
-	Args    []string
+	AppName       string
+	FS            fs.FS
+	Paths         *ConfigPaths
+	AbbrevFlags   bool
+	POSIX         bool
+	ResponseFiles bool
+	StrictEnv     Strictness
+	StrictFlags   Strictness
+	StrictToml    Strictness
+	Warn          func(error)
+	Output        io.Writer
+	constraints   []constraint
+	fileValues    map[string]bool // long flags allowed with FileValues
+	hidden        map[string]bool // long flags hidden with Hide
+	options       []Setter        // least common denominator
+	positionals   []positional
+	sources       map[Setter]string // see Source
+	Args          []string
 }
 
 // NewOptionSet instantiates an OptionSet for a specific application name.
+// Paths is set to the standard ConfigPaths for that name.
 func NewOptionSet(name string) *OptionSet {
-	return &OptionSet{AppName: name}
+	return &OptionSet{AppName: name, Paths: StdConfigPaths(name)}
 }
 
 // Arg is a convenience function for safe access to OptionSet.Args.
 func (o *OptionSet) Arg(i int) string {
-	if i < 0 || i > len(o.Args) {
+	if i < 0 || i >= len(o.Args) {
 		return ""
 	}
 	return o.Args[i]
@@ -44,6 +87,15 @@ func (o *OptionSet) Visit(f func(Setter)) {
 	}
 }
 
+// VisitDir visits DirOpts.
+func (o *OptionSet) VisitDir(f func(DirOpt)) {
+	o.Visit(func(vv Setter) {
+		if v, ok := vv.(DirOpt); ok {
+			f(v)
+		}
+	})
+}
+
 // VisitEnv visits EnvOpts.
 func (o *OptionSet) VisitEnv(f func(EnvOpt)) {
 	o.Visit(func(vv Setter) {
@@ -71,15 +123,82 @@ func (o *OptionSet) VisitToml(f func(TomlOpt)) {
 	})
 }
 
+// Hide hides the options with the given long flags (see HiddenOpt).
+func (o *OptionSet) Hide(flags ...string) {
+	if o.hidden == nil {
+		o.hidden = make(map[string]bool)
+	}
+	for _, v := range flags {
+		o.hidden[v] = true
+	}
+}
+
+// IsHidden reports whether f is hidden, either through HiddenOpt or Hide.
+// Aliases of hidden options are also hidden.
+func (o *OptionSet) IsHidden(f FlagOpt) bool {
+	for _, v := range []FlagOpt{f, unalias(f)} {
+		if h, ok := v.(HiddenOpt); ok && h.Hidden() {
+			return true
+		}
+		if o.hidden[v.Flag()] {
+			return true
+		}
+	}
+	return false
+}
+
 // FindLongFlag returns a FlagOpt based on the "Flag" property.
-func (o *OptionSet) FindLongFlag(s string) (res FlagOpt) {
+// See LookupLongFlag for details; ambiguous abbreviations return nil.
+func (o *OptionSet) FindLongFlag(s string) FlagOpt {
+	res, _ := o.LookupLongFlag(s)
+	return res
+}
+
+// LookupLongFlag returns a FlagOpt based on the "Flag" property.
+//
+// Exact matches are always preferred.
+// If OptionSet.AbbrevFlags is true, it will otherwise look for a flag that s
+// is a unique prefix of (for example, "verb" for "verbose").
+// If there are multiple such flags, it returns ErrAmbiguousFlag listing them.
+func (o *OptionSet) LookupLongFlag(s string) (res FlagOpt, err error) {
+	if s == "" { // options without a long flag (such as short aliases)
+		return nil, nil
+	}
 	o.VisitFlag(func(f FlagOpt) {
 		if f.Flag() == s {
 			res = f
 			return
 		}
 	})
-	return
+	if res != nil || !o.AbbrevFlags {
+		return
+	}
+
+	var (
+		cands   []FlagOpt
+		names   []string
+		targets = map[FlagOpt]bool{} // an option and its aliases are one match
+	)
+	o.VisitFlag(func(f FlagOpt) {
+		if f.Flag() == "" || !strings.HasPrefix(f.Flag(), s) {
+			return
+		}
+		if t := unalias(f); hashable(t) {
+			if targets[t] {
+				return
+			}
+			targets[t] = true
+		}
+		cands = append(cands, f)
+		names = append(names, "--"+f.Flag())
+	})
+	switch len(cands) {
+	case 0:
+		return nil, nil
+	case 1:
+		return cands[0], nil
+	}
+	return nil, errAmbiguousFlag(s, names)
 }
 
 // FindShortFlag returns a FlagOpt based on the "ShortFlag" property.
@@ -96,10 +215,21 @@ func (o *OptionSet) FindShortFlag(c rune) (res FlagOpt) {
 	return
 }
 
-// wrapper for fmt.Fprintf(os.Stderr, ...)
-// this is here in case I want to make this configurable later
+// wrapper for fmt.Fprintf(o.Output, ...), defaulting to os.Stderr
 func (o *OptionSet) print(format string, a ...interface{}) (int, error) {
-	return fmt.Fprintf(os.Stderr, format, a...)
+	if o.Output == nil {
+		return fmt.Fprintf(os.Stderr, format, a...)
+	}
+	return fmt.Fprintf(o.Output, format, a...)
+}
+
+// warn reports a non-fatal error to OptionSet.Warn, or prints it if it's nil.
+func (o *OptionSet) warn(err error) {
+	if o.Warn != nil {
+		o.Warn(err)
+		return
+	}
+	o.print("%s: warning: %v\n", o.AppName, err)
 }
 
 /*
@@ -124,8 +254,10 @@ func (o *OptionSet) printflag(flen, llen int, short bool, f FlagOpt) string {
 			s.WriteString("    ") // - f , ' '
 		}
 	}
-	s.WriteString("--")
-	s.WriteString(f.Flag())
+	if lf := longFlag(f); lf != "" {
+		s.WriteString("--")
+		s.WriteString(lf)
+	}
 
 	for i := flen - s.Len(); i > 0; i-- {
 		s.WriteRune(' ') // align
@@ -164,8 +296,19 @@ func (o *OptionSet) printflag(flen, llen int, short bool, f FlagOpt) string {
 	return s.String()
 }
 
+// longFlag returns the long flag as shown in Usage, without the leading "--".
+func longFlag(f FlagOpt) string {
+	if f.Flag() != "" && negatable(f) {
+		return "[no-]" + f.Flag()
+	}
+	return f.Flag()
+}
+
 /*
 Usage will use AppName and the Options that are FlagOpts to print usage info.
+Hidden options (see HiddenOpt) are left out.
+If there are any positional arguments (see Positional), it starts with a line
+like "usage: AppName [flags] <src> [dst...]".
 
 The format looks like this if there are no short flags:
   AppName:
@@ -184,13 +327,33 @@ Or like this if there is at least one:
         --st   Standalone flag.
 */
 func (o *OptionSet) Usage() {
+	o.usage(false)
+}
+
+// UsageAll is like Usage, but includes hidden options.
+func (o *OptionSet) UsageAll() {
+	o.usage(true)
+}
+
+func (o *OptionSet) usage(all bool) {
+	if len(o.positionals) > 0 {
+		o.print("usage: %s\n", o.synopsis())
+	}
 	o.print("%s:\n", o.AppName)
 	flen := 0
 	llen := 80 // BUG(toast): llen is hardcoded to 80
 	short := false
 
-	o.VisitFlag(func(v FlagOpt) {
-		vlen := len(v.Flag()) + 4 // "  --" -> 4
+	visit := func(f func(FlagOpt)) {
+		o.VisitFlag(func(v FlagOpt) {
+			if all || !o.IsHidden(v) {
+				f(v)
+			}
+		})
+	}
+
+	visit(func(v FlagOpt) {
+		vlen := len(longFlag(v)) + 4 // "  --" -> 4
 		if v.ShortFlag() != 0 {
 			if !short {
 				flen += 4 // - f , ' '; we didn't know we had short flags until now
@@ -206,10 +369,17 @@ func (o *OptionSet) Usage() {
 		}
 	})
 
-	o.VisitFlag(func(f FlagOpt) {
+	visit(func(f FlagOpt) {
 		o.print(o.printflag(flen, llen, short, f))
 	})
 
+	if len(o.constraints) > 0 {
+		o.print("Constraints:\n")
+		for _, v := range o.constraints {
+			o.print("  %s\n", v)
+		}
+	}
+
 	if len(o.Args) > 0 {
 		o.print("Args: %q\n", o.Args)
 	}
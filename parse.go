@@ -9,6 +9,9 @@ If it encounters an error in the middle of a file, it will finish parsing the
 file, but all other files will not be parsed.
 You will only receive the *last* error in the *first* file that fails.
 
+Then, if systemd gave you credentials, it will parse them (see
+ParseCredentials).
+
 After that (assuming no errors), it will try to parse your environment.
 If it encounters an error in your envirnoment, it will still parse the rest.
 You will only receive the error for the *last* option that failed to be set.
@@ -19,6 +22,10 @@ See ParseFlags([]string) for more details as to how and when it might error out.
 Once all parsing is done, it will check to see if you have a Help flag defined.
 If you do, and it's set to true, or if there was an error, Parse() will call
 Usage() for you.
+If you have a HelpAll flag defined and it's set to true, it will call UsageAll()
+instead.
+If neither is set, it will first set your positional arguments (see ParseArgs)
+and check your constraints (see Check).
 
 If you want any other behavior, please write your own handling!
 This is valid and encouraged.
@@ -26,8 +33,8 @@ I.e this function can also serve as an example as to how to set up your own.
 */
 func (o *OptionSet) Parse(ss []string) error {
 	var (
-		help bool
-		err  error
+		help, helpall bool
+		err           error
 	)
 
 	err = o.ParseStdToml()
@@ -35,6 +42,11 @@ func (o *OptionSet) Parse(ss []string) error {
 		goto parse_finish
 	}
 
+	err = o.ParseCredentials()
+	if err != nil {
+		goto parse_finish
+	}
+
 	err = o.ParseEnv()
 	if err != nil {
 		goto parse_finish
@@ -47,13 +59,28 @@ func (o *OptionSet) Parse(ss []string) error {
 
 	// we don't need to try to find "help" if there is an active error
 	o.VisitFlag(func(vv FlagOpt) {
-		if v, ok := vv.(*HelpOpt); ok {
+		switch v := vv.(type) {
+		case *HelpOpt:
 			help = help || bool(*v)
+		case *HelpAllOpt:
+			helpall = helpall || bool(*v)
 		}
 	})
+	if help || helpall {
+		goto parse_finish
+	}
+
+	err = o.ParseArgs()
+	if err != nil {
+		goto parse_finish
+	}
+
+	err = o.Check()
 
 parse_finish:
-	if err != nil || help {
+	if helpall {
+		o.UsageAll()
+	} else if err != nil || help {
 		o.Usage()
 	}
 	return err
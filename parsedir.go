@@ -0,0 +1,43 @@
+package libuconf
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// ParseDir will look for files named `option.File()` within dir.
+// If one is found, it will try to set the option to its contents, without any
+// trailing newlines.
+// Missing files (or a missing dir) are not an error.
+// The files are read from OptionSet.FS.
+func (o *OptionSet) ParseDir(dir string) (e error) {
+	o.VisitDir(func(v DirOpt) {
+		name := path.Join(dir, v.File())
+		b, err := o.readFile(name)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				e = err
+			}
+			return // continue
+		}
+		val := strings.TrimRight(string(b), "\r\n")
+		if err := o.set(v, val, "file "+name); err != nil {
+			e = err
+		}
+	})
+	return
+}
+
+// ParseCredentials runs ParseDir on $CREDENTIALS_DIRECTORY, which is where
+// systemd exposes a service's credentials.
+// If it is not set, it does nothing.
+func (o *OptionSet) ParseCredentials() error {
+	dir, ok := os.LookupEnv("CREDENTIALS_DIRECTORY")
+	if !ok || dir == "" {
+		return nil
+	}
+	return o.ParseDir(dir)
+}
@@ -0,0 +1,38 @@
+package libuconf_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDir(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		host   = o.String("db.host", 0, "", "dbhosthelp")
+		port   = o.Int("db.port", 0, 1, "dbporthelp")
+		user   = o.String("db.user", 0, "nobody", "dbuserhelp")
+	)
+	o.FS = fstest.MapFS{
+		"etc/config/db.host": {Data: []byte("localhost\n")},
+		"etc/config/db.port": {Data: []byte("5432")},
+		"creds/db.user":      {Data: []byte("admin\n")},
+	}
+
+	err := o.ParseDir("/etc/config")
+	assert.Nil(err)
+	assert.Equal("localhost", *host)
+	assert.Equal(int64(5432), *port)
+	assert.Equal("nobody", *user)
+	assert.Equal("file /etc/config/db.host", o.Source(o.FindLongFlag("db.host")))
+
+	assert.Nil(o.ParseDir("/missing"))
+
+	t.Setenv("CREDENTIALS_DIRECTORY", "creds")
+	assert.Nil(o.ParseCredentials())
+	assert.Equal("admin", *user)
+}
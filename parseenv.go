@@ -2,22 +2,74 @@ package libuconf
 
 import (
 	"os"
+	"sort"
 	"strings"
 )
 
 // ParseEnv will look for environment variables APPNAME_`option.Env()`.
 // If one is found, it will try to set it.
+//
+// If the option allows it (see FileValueOpt), and that variable is not set,
+// it will also look for APPNAME_`option.Env()`_FILE, setting the option to the
+// contents of the file it names.
+//
+// If OptionSet.StrictEnv is not Permissive, it will also look for variables
+// starting with APPNAME_ that do not belong to any option.
 func (o *OptionSet) ParseEnv() (e error) {
-	prefix := strings.ToUpper(o.AppName)
+	prefix := strings.ToUpper(o.AppName) + "_"
+	if err := o.checkEnv(prefix); err != nil {
+		return err
+	}
 	o.VisitEnv(func(v EnvOpt) {
-		env := prefix + "_" + v.Env()
+		env := prefix + v.Env()
 		res, ok := os.LookupEnv(env)
 		if !ok {
-			return // continue
+			env += "_FILE"
+			if res, ok = os.LookupEnv(env); !ok || !o.fileValue(v) {
+				return // continue
+			}
+			if err := o.setFile(v, res, "env "+env); err != nil {
+				e = errSet(nil, env, res, err) // res is a path, not a secret
+			}
+			return
 		}
-		if err := v.Set(res); err != nil {
-			e = err
+		if err := o.set(v, res, "env "+env); err != nil {
+			e = errSet(v, env, res, err)
 		}
 	})
 	return
 }
+
+// checkEnv looks for unknown variables, according to OptionSet.StrictEnv.
+func (o *OptionSet) checkEnv(prefix string) error {
+	if o.StrictEnv == Permissive {
+		return nil
+	}
+	var known, unknown []string
+	o.VisitEnv(func(v EnvOpt) {
+		known = append(known, prefix+v.Env())
+		if o.fileValue(v) {
+			known = append(known, prefix+v.Env()+"_FILE")
+		}
+	})
+	for _, kv := range os.Environ() {
+		k := strings.SplitN(kv, "=", 2)[0]
+		if !strings.HasPrefix(k, prefix) || contains(known, k) {
+			continue
+		}
+		unknown = append(unknown, k+didYouMean(k, known))
+	}
+
+	sort.Strings(unknown)
+
+	switch {
+	case len(unknown) == 0:
+		return nil
+	case o.StrictEnv == StrictError:
+		return errUnknownEnv(unknown...)
+	}
+	for _, v := range unknown {
+		o.warn(errUnknownEnv(v))
+	}
+	return nil
+}
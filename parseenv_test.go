@@ -0,0 +1,97 @@
+package libuconf_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnvStrict(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "envtest"}
+		l      = o.String("log.level", 0, "", "loglevelhelp")
+		warns  []error
+	)
+	t.Setenv("ENVTEST_LOG_LEVEL", "debug")
+	t.Setenv("ENVTEST_LOGLEVEL", "info")
+
+	// permissive by default
+	assert.Nil(o.ParseEnv())
+	assert.Equal("debug", *l)
+
+	*l = ""
+	o.StrictEnv = StrictError
+	err := o.ParseEnv()
+	assert.True(errors.Is(err, ErrUnknownEnv))
+	assert.Contains(err.Error(), "ENVTEST_LOGLEVEL (did you mean ENVTEST_LOG_LEVEL?)")
+	assert.Equal("", *l) // nothing gets set
+
+	o.StrictEnv = StrictWarn
+	o.Warn = func(err error) { warns = append(warns, err) }
+	assert.Nil(o.ParseEnv())
+	assert.Equal("debug", *l)
+	assert.Len(warns, 1)
+
+	// errors name the variable
+	o.Int("n", 0, 0, "nhelp")
+	t.Setenv("ENVTEST_N", "five")
+	assert.EqualError(o.ParseEnv(), "failed to set: ENVTEST_N to five: invalid syntax")
+}
+
+func TestParseEnvFile(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "envtest"}
+		pass   = o.String("password", 0, "", "passwordhelp")
+		user   = o.String("user", 0, "", "userhelp")
+	)
+	o.FS = fstest.MapFS{"run/secrets/pass": {Data: []byte("hunter2\n\n")}}
+	t.Setenv("ENVTEST_PASSWORD_FILE", "/run/secrets/pass")
+	t.Setenv("ENVTEST_USER_FILE", "/run/secrets/pass")
+
+	// opt-in only
+	assert.Nil(o.ParseEnv())
+	assert.Equal("", *pass)
+
+	o.FileValues("password")
+	o.StrictEnv = StrictError
+	err := o.ParseEnv()
+	assert.True(errors.Is(err, ErrUnknownEnv))
+	assert.Contains(err.Error(), "ENVTEST_USER_FILE")
+	assert.NotContains(err.Error(), "ENVTEST_PASSWORD_FILE")
+
+	o.StrictEnv = Permissive
+	assert.Nil(o.ParseEnv())
+	assert.Equal("hunter2", *pass)
+	assert.Equal("", *user)
+	assert.Equal("file /run/secrets/pass (env ENVTEST_PASSWORD_FILE)",
+		o.Source(o.FindLongFlag("password")))
+
+	// errors name the variable
+	t.Setenv("ENVTEST_PASSWORD_FILE", "/nope")
+	err = o.ParseEnv()
+	assert.True(errors.Is(err, ErrSet))
+	assert.True(errors.Is(err, fs.ErrNotExist))
+	assert.EqualError(err, "failed to set: ENVTEST_PASSWORD_FILE to /nope: open nope: file does not exist")
+
+	// the flag syntax
+	err = o.ParseFlags([]string{"--user=@/run/secrets/pass", "--password", "@/nope"})
+	assert.True(errors.Is(err, ErrSet))
+	assert.True(errors.Is(err, fs.ErrNotExist))
+	assert.EqualError(err, "failed to set: password to @/nope: open nope: file does not exist")
+	assert.Equal("@/run/secrets/pass", *user)
+
+	// a flag's value is not a response file
+	*pass = ""
+	o.ResponseFiles = true
+	err = o.ParseFlags([]string{"--password", "@/run/secrets/pass"})
+	assert.Nil(err)
+	assert.Equal("hunter2", *pass)
+	assert.Empty(o.Args)
+}
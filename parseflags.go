@@ -1,57 +1,151 @@
 package libuconf
 
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
 /*
 ParseFlags parses a set of args (ss) and modifies the attached options
 
-These formats are allowed:
- -abc value (where a and b are bool types)
- -dvalue
- --a.b.c=value
- --d.e.f value
- --g.h.i (where g.h.i is a bool type)
-notably missing is -a=value, but you shouldn't need it
+These formats are allowed (a, b and c are bools, x and flag may be anything):
+
+	-a           a is set to true
+	-x value     x is set to value
+	-xvalue      x is set to value
+	-x=value     x is set to value
+	-x=          x is set to "" (the empty string)
+	-abc         a, b and c are set to true
+	-abx value   a and b are set to true, x is set to value
+	-abxvalue    a and b are set to true, x is set to value
+	-abx=value   a and b are set to true, x is set to value
+	--a          a is set to true
+	--flag value flag is set to value
+	--flag=value flag is set to value
+	--flag=      flag is set to "" (the empty string)
+	--no-a       a is set to false (if it is negatable, see NegatableOpt)
+	--           all further arguments are added to Args as-is
+
+A value given with "=" (or attached to a short flag) always belongs to its flag.
+A separate value is only used by a bool flag if setting it succeeds, otherwise
+the bool is set to true and the value is added to Args.
+For example, "-a false" sets a to false, but "-a file" sets it to true.
+A flag that needs a value but is followed by another flag (or nothing at all)
+is an error (ErrNoVal).
 
-Note that non-registered flags are valid.
+Note that non-registered flags are valid by default.
 For example, if you did not register a "foobar" flag, "--foobar=y" will be added
 to Args.
+If OptionSet.StrictFlags is not Permissive, such flags (before any "--") are
+instead reported as ErrUnknownFlag, along with close matches.
+Numbers such as "-5" are never considered to be flags.
+
+If OptionSet.POSIX is true, or the POSIXLY_CORRECT environment variable is set,
+the first argument that isn't a flag (or a flag's value) ends flag parsing, as
+if "--" had been given right before it.
+This allows for things like "app exec cmd --cmd-flag".
+
+If OptionSet.ResponseFiles is true, any "@path" argument is replaced with the
+arguments contained in that file, which is read from OptionSet.FS.
+Like flags, they are only looked for before "--" (or, in POSIX mode, the first
+argument), and never as the value of a flag that needs one.
+Response files are split like a shell would: whitespace separates arguments,
+quotes and backslashes work as usual, and "#" starts a comment.
+They may refer to other response files, relative to themselves.
+Errors are reported as ErrResponseFile, naming the file and line, which wraps
+any error caused by an argument from that file (such as ErrSet).
+
+Options that allow it (see FileValueOpt) may be given a value of "@path", in
+which case they are set to the contents of path instead.
 */
 func (o *OptionSet) ParseFlags(ss []string) error {
+	var a respArg // the argument being parsed
+	err := o.parseFlags(ss, &a)
+	if err != nil && a.file != "" && !errors.Is(err, ErrResponseFile) {
+		return errResponse(a.file, a.line, err)
+	}
+	return err
+}
+
+// parseFlags implements ParseFlags, keeping a up to date with the argument it
+// is parsing, so errors can name the response file it came from.
+func (o *OptionSet) parseFlags(ss []string, a *respArg) error {
 	var (
 		done, handled bool
+		posix         = o.posix()
 		handling      FlagOpt
 		name          string // name on handling
+		src           string // source of handling, see OptionSet.Source
+		args          = make([]respArg, len(ss))
 	)
+	for i, v := range ss {
+		args[i] = respArg{s: v}
+	}
 
-	for _, s := range ss {
+	for len(args) > 0 {
+		*a = args[0]
+		args = args[1:]
+		s := a.s
 		if done { // we finished parsing args, so just pass the rest through
 			o.Args = append(o.Args, s)
 			continue
 		}
 
-		var (
-			opt, val1  = o.getLongFlag(s)
-			opts, val2 = o.getShortFlags(s)
-		)
+		// response files are expanded as they're reached, unless they're the
+		// value a flag needs (such as "--token @file", see FileValueOpt)
+		needval := handling != nil && !handled && !handling.Bool()
+		if o.ResponseFiles && isResponse(s) && !needval {
+			more, err := o.expandArg(*a)
+			if err != nil {
+				return err
+			}
+			args = append(more, args...)
+			continue
+		}
+
+		long, err := o.getLongFlag(s)
+		if err != nil {
+			return err
+		}
+		opts, val2, hasval2 := o.getShortFlags(s)
 
 		// we never did the last flag!
-		if (opt != nil || opts != nil) && handling != nil && !handled {
+		if (long.opt != nil || opts != nil) && handling != nil && !handled {
 			if !handling.Bool() { // needed a value, but we have a flag
 				return errNoVal(name)
 			}
-			if err := handling.Set(true); err != nil { // can't set to true
-				return errSet(name, "true")
+			if err := o.set(handling, true, src); err != nil { // can't set to true
+				return errSet(handling, name, "true", nil)
 			}
 		}
 
 		// long flag
-		if opt != nil {
-			handling = opt
+		if long.opt != nil && long.neg { // --no-flag never takes a value
+			o.warnDeprecated(long.opt, "--no-")
+			handling = long.opt
+			handled = true
+			name = "no-" + handling.Flag()
+			src = "flag --" + name
+			if long.hasval {
+				return errSet(handling, name, long.val, nil)
+			}
+			if err := o.set(long.opt, false, src); err != nil {
+				return errSet(handling, name, "false", nil)
+			}
+			continue
+		}
+		if long.opt != nil {
+			o.warnDeprecated(long.opt, "--")
+			handling = long.opt
 			handled = false
 			name = handling.Flag()
+			src = "flag --" + name
 
-			if len(val1) > 0 { // it came with a value!
-				if err := opt.Set(val1); err != nil {
-					return errSet(name, val1)
+			if long.hasval { // it came with a value!
+				if err := o.setFlag(long.opt, long.val, src); err != nil {
+					return o.errFlag(handling, name, long.val, err)
 				}
 				handled = true
 			}
@@ -61,20 +155,22 @@ func (o *OptionSet) ParseFlags(ss []string) error {
 		// short flag
 		if l := len(opts); l > 0 {
 			for i, v := range opts { // range of nil = skip
+				o.warnDeprecated(v, "-")
 				handling = v
 				handled = false
 				name = string(handling.ShortFlag())
+				src = "flag -" + name
 
 				if i != l-1 { // NOT the last opt - set bool to true, guaranteed by func
-					if err := v.Set(true); err != nil {
-						return errSet(name, "true")
+					if err := o.set(v, true, src); err != nil {
+						return errSet(handling, name, "true", nil)
 					}
 					continue
 				} // it IS the last opt, check for value
-				if val2 != "" { // there is one!
+				if hasval2 { // there is one!
 					handled = true
-					if err := v.Set(val2); err != nil {
-						return errSet(name, val2)
+					if err := o.setFlag(v, val2, src); err != nil {
+						return o.errFlag(handling, name, val2, err)
 					}
 				}
 			}
@@ -89,20 +185,26 @@ func (o *OptionSet) ParseFlags(ss []string) error {
 
 		// ok, so it *must* be a value or an arg
 		if handled || handling == nil {
-			o.Args = append(o.Args, s) // this is easy
+			if err := o.passArg(s); err != nil { // this is easy
+				return err
+			}
+			done = posix && !isFlag(s)
 			continue
 		}
 
 		// so the previous flag isn't handled, but we have a value
 		// so if the value fails to apply, bools should just be set
-		err := handling.Set(s)             // try to set
+		err = o.setFlag(handling, s, src)  // try to set
 		if err != nil && handling.Bool() { // it failed, but the flag is bool
-			o.Args = append(o.Args, s) // save s before we override it
-			err = handling.Set(true)   // try to set again, resetting err
-			s = "true"                 // set s to true for errSet
+			if err := o.passArg(s); err != nil { // save s before we override it
+				return err
+			}
+			done = posix && !isFlag(s)
+			err = o.set(handling, true, src) // try to set again, resetting err
+			s = "true"                       // set s to true for errSet
 		}
 		if err != nil { // if there's still an error, error out
-			return errSet(name, s)
+			return o.errFlag(handling, name, s, err)
 		}
 		handled = true // there was no error
 	}
@@ -110,8 +212,8 @@ func (o *OptionSet) ParseFlags(ss []string) error {
 	// the last string was a flag, and we didn't handle it
 	if !handled && handling != nil {
 		if handling.Bool() { // if it's a boolean, set it to true
-			if err := handling.Set(true); err != nil { // can't set to true
-				return errSet(name, "true")
+			if err := o.set(handling, true, src); err != nil { // can't set to true
+				return errSet(handling, name, "true", nil)
 			}
 		} else {
 			return errNoVal(name)
@@ -121,10 +223,20 @@ func (o *OptionSet) ParseFlags(ss []string) error {
 	return nil
 }
 
-func (o *OptionSet) getLongFlag(s string) (FlagOpt, string) {
+// longArg is a long flag, as found by getLongFlag.
+type longArg struct {
+	opt    FlagOpt
+	val    string
+	hasval bool // there was a "=", even if val is empty
+	neg    bool // it was given as --no-flag
+}
+
+// getLongFlag returns the long flag in s, if there is one.
+// An error is only returned for ambiguous abbreviations.
+func (o *OptionSet) getLongFlag(s string) (longArg, error) {
 	if len(s) < 3 || s[0] != '-' || s[1] != '-' {
 		// not a long flag
-		return nil, ""
+		return longArg{}, nil
 	}
 	var (
 		flag    []rune
@@ -145,14 +257,28 @@ func (o *OptionSet) getLongFlag(s string) (FlagOpt, string) {
 			flag = append(flag, v)
 		}
 	}
-	opt := o.FindLongFlag(string(flag))
-	return opt, string(val)
+	out := longArg{val: string(val), hasval: foundeq}
+
+	opt, err := o.LookupLongFlag(string(flag))
+	if err != nil || opt != nil || !strings.HasPrefix(string(flag), "no-") {
+		out.opt = opt
+		return out, err
+	}
+	// --no-flag
+	opt, err = o.LookupLongFlag(string(flag[3:]))
+	if err != nil || opt == nil || !negatable(opt) {
+		return longArg{}, err
+	}
+	out.opt, out.neg = opt, true
+	return out, nil
 }
 
-func (o *OptionSet) getShortFlags(s string) ([]FlagOpt, string) {
+// getShortFlags returns the flags in s, the value for the last one, and
+// whether there was a value.
+func (o *OptionSet) getShortFlags(s string) ([]FlagOpt, string, bool) {
 	if len(s) < 2 || s[0] != '-' || s[1] == '-' {
 		// not a short flag
-		return nil, ""
+		return nil, "", false
 	}
 	var (
 		res  []FlagOpt
@@ -177,9 +303,47 @@ func (o *OptionSet) getShortFlags(s string) ([]FlagOpt, string) {
 			res = append(res, opt)
 		}
 	}
-	out := ""
-	if val != nil {
-		out = string(val)
+	if res != nil && len(val) > 0 && val[0] == '=' { // -x=value
+		return res, string(val[1:]), true
+	}
+	return res, string(val), val != nil
+}
+
+// passArg adds s to Args, first checking if it's an unknown flag.
+// What happens to unknown flags depends on OptionSet.StrictFlags.
+func (o *OptionSet) passArg(s string) error {
+	if o.StrictFlags != Permissive && isFlag(s) {
+		var (
+			flag  = strings.SplitN(s, "=", 2)[0]
+			names []string
+		)
+		o.VisitFlag(func(f FlagOpt) {
+			if f.Flag() != "" {
+				names = append(names, "--"+f.Flag())
+			}
+		})
+		err := errUnknownFlag(flag, didYouMean(flag, names))
+		if o.StrictFlags == StrictError {
+			return err
+		}
+		o.warn(err)
+	}
+	o.Args = append(o.Args, s)
+	return nil
+}
+
+// posix reports whether flag parsing should stop at the first argument.
+func (o *OptionSet) posix() bool {
+	_, ok := os.LookupEnv("POSIXLY_CORRECT")
+	return o.POSIX || ok
+}
+
+// isFlag reports whether s looks like a flag, as opposed to an argument.
+// "-" (commonly stdin) and negative numbers are arguments.
+func isFlag(s string) bool {
+	if len(s) < 2 || s[0] != '-' {
+		return false
 	}
-	return res, out
+	_, err := strconv.ParseFloat(s, 64)
+	return err != nil
 }
@@ -1,7 +1,12 @@
 package libuconf_test
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	. "toast.cafe/x/libuconf"
 
@@ -247,3 +252,397 @@ func TestParseShortFLagsBool(t *testing.T) {
 	})
 	assert.NotNil(err)
 }
+
+func TestParseFlagsStrict(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		v      = o.Bool("verbose", 'v', false, "verbosehelp")
+		warns  []error
+	)
+
+	// permissive by default
+	err := o.ParseFlags([]string{"--verbos", "-x"})
+	assert.Nil(err)
+	assert.Equal([]string{"--verbos", "-x"}, o.Args)
+
+	o.Args = nil
+	o.StrictFlags = StrictError
+	err = o.ParseFlags([]string{"--verbos=true"})
+	assert.True(errors.Is(err, ErrUnknownFlag))
+	assert.Contains(err.Error(), "--verbos (did you mean --verbose?)")
+
+	err = o.ParseFlags([]string{"-v", "-x"})
+	assert.True(errors.Is(err, ErrUnknownFlag))
+
+	// not flags: "-", numbers, anything after "--"
+	o.Args = nil
+	err = o.ParseFlags([]string{"-", "-5", "--", "--verbos"})
+	assert.Nil(err)
+	assert.Equal([]string{"-", "-5", "--verbos"}, o.Args)
+
+	o.Args = nil
+	o.StrictFlags = StrictWarn
+	o.Warn = func(err error) { warns = append(warns, err) }
+	err = o.ParseFlags([]string{"--verbos", "-v"})
+	assert.Nil(err)
+	assert.True(*v)
+	assert.Equal([]string{"--verbos"}, o.Args)
+	assert.Len(warns, 1)
+}
+
+func TestParseLongFlagsNegate(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.Bool("aa", 0, true, "aahelp")
+		s      = o.String("ss", 0, "", "sshelp")
+		n      = o.Int("nn", 0, 5, "nnhelp")
+	)
+	o.Help()
+
+	err := o.ParseFlags([]string{"--no-aa", "arbitrary"})
+	assert.Nil(err)
+	assert.Equal(false, *a)
+	assert.Equal([]string{"arbitrary"}, o.Args)
+
+	// negated flags never take values
+	o.Args = nil
+	err = o.ParseFlags([]string{"--no-aa=true"})
+	assert.NotNil(err)
+
+	// only negatable options can be negated
+	err = o.ParseFlags([]string{"--no-ss", "--no-help", "--no-nn"})
+	assert.Nil(err)
+	assert.Equal("", *s)
+	assert.Equal(int64(5), *n)
+	assert.Equal([]string{"--no-ss", "--no-help", "--no-nn"}, o.Args)
+}
+
+// every syntax documented in ParseFlags
+func TestParseFlagsSyntax(t *testing.T) {
+	const u = "unset"
+	matrix := []struct {
+		in      []string
+		a, b, c bool
+		x, flag string
+		args    []string
+	}{
+		{[]string{"-a"}, true, false, false, u, u, nil},
+		{[]string{"-x", "value"}, false, false, false, "value", u, nil},
+		{[]string{"-xvalue"}, false, false, false, "value", u, nil},
+		{[]string{"-x=value"}, false, false, false, "value", u, nil},
+		{[]string{"-x=", "arg"}, false, false, false, "", u, []string{"arg"}},
+		{[]string{"-x=a=b"}, false, false, false, "a=b", u, nil},
+		{[]string{"-abc"}, true, true, true, u, u, nil},
+		{[]string{"-abx", "value"}, true, true, false, "value", u, nil},
+		{[]string{"-abxvalue"}, true, true, false, "value", u, nil},
+		{[]string{"-abx=value"}, true, true, false, "value", u, nil},
+		{[]string{"-a=false"}, false, false, false, u, u, nil},
+		{[]string{"-a", "false"}, false, false, false, u, u, nil},
+		{[]string{"-a", "file"}, true, false, false, u, u, []string{"file"}},
+		{[]string{"--a"}, true, false, false, u, u, nil},
+		{[]string{"--flag", "value"}, false, false, false, u, "value", nil},
+		{[]string{"--flag=value"}, false, false, false, u, "value", nil},
+		{[]string{"--flag=", "arg"}, false, false, false, u, "", []string{"arg"}},
+		{[]string{"--a", "--no-a"}, false, false, false, u, u, nil},
+		{[]string{"--", "-a", "--flag"}, false, false, false, u, u, []string{"-a", "--flag"}},
+	}
+	for _, v := range matrix {
+		var (
+			assert = assert.New(t)
+			o      = &OptionSet{AppName: "test"}
+			a      = o.Bool("a", 'a', false, "ahelp")
+			b      = o.Bool("b", 'b', false, "bhelp")
+			c      = o.Bool("c", 'c', false, "chelp")
+			x      = o.String("x", 'x', u, "xhelp")
+			flag   = o.String("flag", 0, u, "flaghelp")
+		)
+
+		err := o.ParseFlags(v.in)
+		assert.Nil(err, v.in)
+		assert.Equal(v.a, *a, v.in)
+		assert.Equal(v.b, *b, v.in)
+		assert.Equal(v.c, *c, v.in)
+		assert.Equal(v.x, *x, v.in)
+		assert.Equal(v.flag, *flag, v.in)
+		assert.Equal(v.args, o.Args, v.in)
+	}
+}
+
+func TestParseLongFlagsAbbrev(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		o       = &OptionSet{AppName: "test"}
+		verbose = o.Bool("verbose", 0, false, "verbosehelp")
+		version = o.Bool("version", 0, false, "versionhelp")
+		verb    = o.String("verb", 0, "", "verbhelp")
+	)
+
+	// off by default
+	assert.Nil(o.FindLongFlag("verbo"))
+
+	o.AbbrevFlags = true
+	assert.NotNil(o.FindLongFlag("verbo"))
+
+	// exact matches win
+	err := o.ParseFlags([]string{"--verb", "run", "--verbo", "--vers"})
+	assert.Nil(err)
+	assert.Equal("run", *verb)
+	assert.True(*verbose)
+	assert.True(*version)
+
+	err = o.ParseFlags([]string{"--no-verbo"})
+	assert.Nil(err)
+	assert.False(*verbose)
+
+	_, err = o.LookupLongFlag("ver")
+	assert.True(errors.Is(err, ErrAmbiguousFlag))
+	assert.Equal("ambiguous flag: --ver could be --verbose or --version or --verb",
+		err.Error())
+	assert.Equal(err, o.ParseFlags([]string{"--ver"}))
+}
+
+func TestParseFlagsAlias(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		color  = o.Bool("color", 0, false, "colorhelp")
+		out    = o.String("output", 'o', "", "outputhelp")
+		warns  []error
+	)
+	o.Warn = func(err error) { warns = append(warns, err) }
+	assert.Nil(o.Alias("color", "colour", 'C'))
+	assert.Nil(o.DeprecatedAlias("output", "out-file", 'f'))
+	assert.True(errors.Is(o.Alias("nope", "nah", 0), ErrUnknownFlag))
+
+	assert.Equal("colour", o.FindLongFlag("colour").Flag())
+	assert.Equal("colour", o.FindShortFlag('C').Flag())
+
+	err := o.ParseFlags([]string{"--colour", "-f", "file"})
+	assert.Nil(err)
+	assert.True(*color)
+	assert.Equal("file", *out)
+	assert.Len(warns, 1)
+	assert.True(errors.Is(warns[0], ErrDeprecated))
+	assert.EqualError(warns[0], "deprecated flag: -f, use --output instead")
+
+	err = o.ParseFlags([]string{"--no-colour", "--out-file=other"})
+	assert.Nil(err)
+	assert.False(*color)
+	assert.Equal("other", *out)
+	assert.Len(warns, 2)
+	assert.EqualError(warns[1], "deprecated flag: --out-file, use --output instead")
+
+	// a bool that does not take the value is still only warned about once
+	verbose := o.Bool("verbose", 0, false, "verbosehelp")
+	assert.Nil(o.DeprecatedAlias("verbose", "old-verbose", 0))
+	err = o.ParseFlags([]string{"--old-verbose", "file"})
+	assert.Nil(err)
+	assert.True(*verbose)
+	assert.Len(warns, 3)
+	err = o.ParseFlags([]string{"--no-old-verbose"})
+	assert.Nil(err)
+	assert.False(*verbose)
+	assert.EqualError(warns[3], "deprecated flag: --no-old-verbose, use --no-verbose instead")
+
+	// short-only aliases have no long flag to match
+	assert.Nil(o.Alias("output", "", 'o'))
+	assert.Nil(o.FindLongFlag(""))
+	o.Args = nil
+	err = o.ParseFlags([]string{"--=other"})
+	assert.Nil(err)
+	assert.Equal("other", *out)
+	assert.Equal([]string{"--=other"}, o.Args)
+	o.Args = nil
+	o.StrictFlags = StrictError
+	err = o.ParseFlags([]string{"--x"})
+	assert.True(errors.Is(err, ErrUnknownFlag))
+	assert.NotContains(err.Error(), "-- ")
+	assert.NotContains(err.Error(), "--?")
+	o.StrictFlags = Permissive
+
+	// an option and its aliases are not ambiguous
+	o.AbbrevFlags = true
+	err = o.ParseFlags([]string{"--col"})
+	assert.Nil(err)
+	assert.True(*color)
+}
+
+func TestParseFlagsHidden(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		debug  = o.Bool("debug", 0, false, "debughelp")
+		_      = o.Bool("verbose", 'v', false, "verbosehelp")
+	)
+	o.Hide("debug")
+	assert.Nil(o.Alias("debug", "dbg", 0))
+
+	assert.True(o.IsHidden(o.FindLongFlag("debug")))
+	assert.True(o.IsHidden(o.FindLongFlag("dbg")))
+	assert.False(o.IsHidden(o.FindLongFlag("verbose")))
+
+	// still parseable
+	err := o.ParseFlags([]string{"--debug"})
+	assert.Nil(err)
+	assert.True(*debug)
+
+	// but only shown by UsageAll
+	var b strings.Builder
+	o.Output = &b
+	o.Usage()
+	assert.Contains(b.String(), "--[no-]verbose")
+	assert.NotContains(b.String(), "debug")
+	assert.NotContains(b.String(), "dbg")
+	b.Reset()
+	o.UsageAll()
+	assert.Contains(b.String(), "--[no-]verbose")
+	assert.Contains(b.String(), "--[no-]debug")
+	assert.Contains(b.String(), "--[no-]dbg")
+
+	// which --help-all calls
+	b.Reset()
+	o.FS = fstest.MapFS{}
+	o.HelpAll()
+	assert.Nil(o.Parse([]string{"--help-all"}))
+	assert.Contains(b.String(), "--[no-]debug")
+	assert.Contains(b.String(), "--help-all")
+}
+
+func TestParseFlagsPOSIX(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.Bool("aa", 'a', false, "aahelp")
+		s      = o.String("ss", 's', "", "sshelp")
+	)
+	o.POSIX = true
+
+	err := o.ParseFlags([]string{"-s", "val", "--unknown", "exec", "cmd", "-a", "--", "--ss=x"})
+	assert.Nil(err)
+	assert.Equal("val", *s)
+	assert.False(*a)
+	assert.Equal([]string{"--unknown", "exec", "cmd", "-a", "--", "--ss=x"}, o.Args)
+
+	// a bool's value is not an argument, but what it can't take is
+	o.Args = nil
+	err = o.ParseFlags([]string{"-a", "false", "-a", "cmd", "-s", "x"})
+	assert.Nil(err)
+	assert.True(*a)
+	assert.Equal([]string{"cmd", "-s", "x"}, o.Args)
+
+	o.Args = nil
+	o.POSIX = false
+	t.Setenv("POSIXLY_CORRECT", "")
+	err = o.ParseFlags([]string{"cmd", "-s", "y"})
+	assert.Nil(err)
+	assert.Equal("val", *s)
+	assert.Equal([]string{"cmd", "-s", "y"}, o.Args)
+}
+
+func TestParseFlagsResponseFiles(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.Bool("aa", 'a', false, "aahelp")
+		s      = o.String("ss", 's', "", "sshelp")
+	)
+	o.Int("nn", 0, 0, "nnhelp")
+	o.FS = fstest.MapFS{
+		"args.rsp": {Data: []byte(
+			"# a comment\n-a --ss 'two words' # another\n\"q\\\"uoted\" back\\ slash @sub/more.rsp\n")},
+		"sub/more.rsp": {Data: []byte("last\n")},
+		"bad.rsp":      {Data: []byte("ok\n'unterminated\n")},
+		"loop.rsp":     {Data: []byte("@loop.rsp")},
+		"cont.rsp":     {Data: []byte("x \\\n y\\\nz \\\n\n")},
+		"val.rsp":      {Data: []byte("-a\n\n  --nn=five\n")},
+		"noval.rsp":    {Data: []byte("-a\n--ss\n")},
+		"flag.rsp":     {Data: []byte("# comment\n-a 'x\ny' --nope\n")},
+	}
+
+	// off by default
+	err := o.ParseFlags([]string{"@args.rsp"})
+	assert.Nil(err)
+	assert.Equal([]string{"@args.rsp"}, o.Args)
+
+	o.Args = nil
+	o.ResponseFiles = true
+	err = o.ParseFlags([]string{"@args.rsp", "--", "@args.rsp"})
+	assert.Nil(err)
+	assert.True(*a)
+	assert.Equal("two words", *s)
+	assert.Equal([]string{"q\"uoted", "back slash", "last", "@args.rsp"}, o.Args)
+
+	// line continuations never make arguments of their own
+	o.Args = nil
+	assert.Nil(o.ParseFlags([]string{"@cont.rsp"}))
+	assert.Equal([]string{"x", "yz"}, o.Args)
+
+	err = o.ParseFlags([]string{"@bad.rsp"})
+	assert.True(errors.Is(err, ErrResponseFile))
+	assert.Contains(err.Error(), "bad.rsp:2: unterminated quote")
+
+	err = o.ParseFlags([]string{"@missing.rsp"})
+	assert.True(errors.Is(err, ErrResponseFile))
+	assert.True(errors.Is(err, fs.ErrNotExist))
+
+	// errors caused by arguments in the file name where they are
+	err = o.ParseFlags([]string{"@val.rsp"})
+	assert.True(errors.Is(err, ErrResponseFile))
+	assert.True(errors.Is(err, ErrSet))
+	assert.EqualError(err, "invalid response file: val.rsp:3: failed to set: nn to five: invalid syntax")
+	err = o.ParseFlags([]string{"@noval.rsp"})
+	assert.True(errors.Is(err, ErrNoVal))
+	assert.EqualError(err, "invalid response file: noval.rsp:2: missing value: ss")
+	o.StrictFlags = StrictError
+	err = o.ParseFlags([]string{"@flag.rsp"})
+	assert.True(errors.Is(err, ErrUnknownFlag))
+	assert.EqualError(err, "invalid response file: flag.rsp:3: unknown flag: --nope")
+	o.StrictFlags = Permissive
+	err = o.ParseFlags([]string{"--nn=five", "@val.rsp"})
+	assert.EqualError(err, "failed to set: nn to five: invalid syntax")
+
+	err = o.ParseFlags([]string{"@loop.rsp"})
+	assert.True(errors.Is(err, ErrResponseFile))
+
+	// in POSIX mode, nothing after the first argument is expanded
+	o.Args = nil
+	o.POSIX = true
+	err = o.ParseFlags([]string{"-s", "x", "exec", "gcc", "@missing.rsp", "@args.rsp"})
+	assert.Nil(err)
+	assert.Equal("x", *s)
+	assert.Equal([]string{"exec", "gcc", "@missing.rsp", "@args.rsp"}, o.Args)
+
+	// including the first argument coming from a response file
+	o.Args = nil
+	err = o.ParseFlags([]string{"@sub/more.rsp", "@missing.rsp"})
+	assert.Nil(err)
+	assert.Equal([]string{"last", "@missing.rsp"}, o.Args)
+}
+
+func TestParseFlagsSecret(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		tok    = o.Secret("token", 't', "", "tokenhelp")
+		opt    = o.FindLongFlag("token")
+	)
+	assert.Equal("", opt.(Getter).Get())
+
+	err := o.ParseFlags([]string{"-t", "hunter2"})
+	assert.Nil(err)
+	assert.Equal("hunter2", *tok)
+	assert.Equal("hunter2", opt.(*SecretOpt).Secret())
+	assert.Equal("<redacted>", opt.(Getter).Get())
+	assert.Equal("<redacted>", fmt.Sprint(opt))
+
+	// files are opt-in, and their paths aren't secret
+	assert.Nil(o.ParseFlags([]string{"--token=@/missing"}))
+	assert.Equal("@/missing", *tok)
+	o.FileValues("token")
+	assert.Nil(o.Alias("token", "api-token", 0))
+	err = o.ParseFlags([]string{"--api-token=@/missing"})
+	assert.True(errors.Is(err, ErrSet))
+	assert.Contains(err.Error(), "failed to set: api-token to @/missing: open /missing")
+}
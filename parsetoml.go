@@ -1,19 +1,135 @@
 package libuconf
 
 import (
-	"os"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
 
 	"github.com/pelletier/go-toml"
 )
 
-// ParseTomlFile parses a toml file, looking for options that implement TomlOpt.
-// If there is an error, it is returned, even if it's just a missing file.
+// the reserved key used for includes, and how deep they may nest
+const (
+	tomlInclude     = "include"
+	maxIncludeDepth = 16
+)
+
+var errIncludeFormat = errors.New("must be a string or an array of strings")
+
+/*
+ParseTomlFile parses a toml file, looking for options that implement TomlOpt.
+If there is an error, it is returned, even if it's just a missing file.
+The file is read from OptionSet.FS.
+Errors found within the file are prefixed with its path.
+
+The top-level "include" key is reserved: it may be a string or an array of
+strings, naming other files to parse before this one.
+Relative paths are resolved against the directory of the including file, and
+glob patterns in file names (such as "secrets/*.toml") are expanded in lexical
+order.
+Included files are parsed in the order they are listed, and the including file
+is parsed last, meaning its values take precedence over included ones.
+A missing file is an error, but a glob pattern matching nothing is not.
+Includes may themselves include other files, up to a depth of 16.
+Including a file that is already being parsed is an error, unless it was
+matched by a glob pattern, in which case it is skipped.
+
+If OptionSet.StrictToml is not Permissive, keys that do not belong to any
+TomlOpt are reported along with their line, and suggestions if any are close.
+*/
 func (o *OptionSet) ParseTomlFile(path string) error {
-	tree, err := toml.LoadFile(path)
+	return o.parseTomlFile(path, nil)
+}
+
+func (o *OptionSet) parseTomlFile(name string, stack []string) error {
+	b, err := o.readFile(name)
+	if err != nil {
+		return err
+	}
+	tree, err := toml.LoadBytes(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err = o.checkToml(tree, name); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err = o.parseTomlIncludes(tree, name, stack); err != nil {
+		return err
+	}
+	if err = o.parseTomlTree(tree, "file "+name); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// parseTomlIncludes parses the files included by t (which came from name).
+// stack is the list of files currently being parsed.
+func (o *OptionSet) parseTomlIncludes(t *toml.Tree, name string, stack []string) error {
+	var pats []string
+	switch v := t.Get(tomlInclude).(type) {
+	case nil:
+		return nil
+	case string:
+		pats = []string{v}
+	case []interface{}:
+		for _, vv := range v {
+			s, ok := vv.(string)
+			if !ok {
+				return errInclude(name, errIncludeFormat)
+			}
+			pats = append(pats, s)
+		}
+	default:
+		return errInclude(name, errIncludeFormat)
+	}
+
+	if len(stack) >= maxIncludeDepth {
+		return errInclude(name, errors.New("includes nested too deeply"))
+	}
+	stack = append(stack, path.Clean(name))
+
+	for _, pat := range pats {
+		if !path.IsAbs(pat) {
+			pat = path.Join(path.Dir(name), pat)
+		}
+		files := []string{pat}
+		glob := hasMeta(pat)
+		if glob {
+			var err error
+			if files, err = o.glob(pat); err != nil {
+				return errInclude(pat, err)
+			}
+		}
+		for _, f := range files {
+			if contains(stack, path.Clean(f)) {
+				if glob { // "*.toml" may well match the includer
+					continue
+				}
+				return errInclude(f, fmt.Errorf("cycle: %s",
+					strings.Join(append(stack, f), " -> ")))
+			}
+			if err := o.parseTomlFile(f, stack); err != nil {
+				if errors.Is(err, ErrInclude) {
+					return err
+				}
+				return errInclude("", err) // err already names f
+			}
+		}
+	}
+	return nil
+}
+
+// ParseTomlDir parses every ".toml" file in a directory, in lexical order.
+// As with ParseTomlFiles, a missing directory is not an error.
+func (o *OptionSet) ParseTomlDir(dir string) error {
+	files, err := o.glob(path.Join(dir, "*.toml"))
 	if err != nil {
 		return err
 	}
-	return o.parseTomlTree(tree)
+	return o.ParseTomlFiles(files...)
 }
 
 // ParseTomlFiles is a convenience function to run multiple ParseTomlFile().
@@ -21,7 +137,8 @@ func (o *OptionSet) ParseTomlFile(path string) error {
 func (o *OptionSet) ParseTomlFiles(paths ...string) error {
 	for _, v := range paths {
 		if err := o.ParseTomlFile(v); err != nil {
-			if os.IsNotExist(err) { // ignore missing files
+			// ignore missing files, but not missing includes
+			if errors.Is(err, fs.ErrNotExist) && !errors.Is(err, ErrInclude) {
 				continue
 			}
 			return err
@@ -30,24 +147,128 @@ func (o *OptionSet) ParseTomlFiles(paths ...string) error {
 	return nil
 }
 
+// ParseStdToml parses the standard configuration files, in order.
+// The paths are resolved against OptionSet.FS.
+// See StdTomlFiles for the list of files.
+func (o *OptionSet) ParseStdToml() error {
+	files, err := o.StdTomlFiles()
+	if err != nil {
+		return err
+	}
+	return o.ParseTomlFiles(files...)
+}
+
+// StdTomlFiles returns the files ParseStdToml considers, in the order they are
+// parsed (later files take precedence).
+// They are derived from OptionSet.Paths (see ConfigPaths), with drop-in
+// directories expanded to the fragments they currently contain.
+func (o *OptionSet) StdTomlFiles() ([]string, error) {
+	var out []string
+	for _, v := range o.configPaths().Files("toml") {
+		if !hasMeta(v) {
+			out = append(out, v)
+			continue
+		}
+		files, err := o.glob(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, files...)
+	}
+	return out, nil
+}
+
 // ParseTomlString parses a string containing TOML data.
+// Includes (see ParseTomlFile) are resolved relative to the current directory.
 func (o *OptionSet) ParseTomlString(c string) error {
 	tree, err := toml.Load(c)
 	if err != nil {
 		return err
 	}
-	return o.parseTomlTree(tree)
+	if err = o.checkToml(tree, ""); err != nil {
+		return err
+	}
+	if err = o.parseTomlIncludes(tree, "", nil); err != nil {
+		return err
+	}
+	return o.parseTomlTree(tree, "toml string")
 }
 
-func (o *OptionSet) parseTomlTree(t *toml.Tree) (e error) {
+// parseTomlTree sets options from t, which came from src (see Source).
+func (o *OptionSet) parseTomlTree(t *toml.Tree, src string) (e error) {
 	o.VisitToml(func(v TomlOpt) {
 		out := t.Get(v.Toml())
 		if out == nil {
 			return
 		}
-		if err := v.Set(out); err != nil {
+		if err := o.set(v, out, src); err != nil {
 			e = err
 		}
 	})
 	return
 }
+
+// checkToml looks for unknown keys in t, according to OptionSet.StrictToml.
+// If it warns, the warnings are prefixed with name (unless it's empty).
+func (o *OptionSet) checkToml(t *toml.Tree, name string) error {
+	if o.StrictToml == Permissive {
+		return nil
+	}
+	known := []string{tomlInclude}
+	o.VisitToml(func(v TomlOpt) {
+		known = append(known, v.Toml())
+	})
+
+	var unknown []string
+	walkToml(t, "", func(key string, pos toml.Position, table bool) bool {
+		for _, k := range known {
+			if k == key {
+				return false // consumed as a whole
+			}
+			if strings.HasPrefix(k, key+".") {
+				return true // a table some option is within
+			}
+		}
+		if table { // report the keys within instead
+			return true
+		}
+		unknown = append(unknown, fmt.Sprintf("line %d: %s%s",
+			pos.Line, key, didYouMean(key, known)))
+		return false
+	})
+
+	switch {
+	case len(unknown) == 0:
+		return nil
+	case o.StrictToml == StrictError:
+		return errUnknownKey(unknown...)
+	}
+	for _, v := range unknown {
+		err := errUnknownKey(v)
+		if name != "" {
+			err = fmt.Errorf("%s: %w", name, err)
+		}
+		o.warn(err)
+	}
+	return nil
+}
+
+// walkToml calls f for every key in t, depth-first, in order of appearance.
+// Sub-tables are only descended into if f returns true.
+func walkToml(t *toml.Tree, prefix string, f func(string, toml.Position, bool) bool) {
+	keys := t.Keys()
+	sort.Slice(keys, func(i, j int) bool {
+		pi, pj := t.GetPositionPath(keys[i:i+1]), t.GetPositionPath(keys[j:j+1])
+		return pi.Line < pj.Line || (pi.Line == pj.Line && pi.Col < pj.Col)
+	})
+	for _, k := range keys {
+		var (
+			kpath = []string{k}
+			key   = prefix + k
+		)
+		sub, table := t.GetPath(kpath).(*toml.Tree)
+		if f(key, t.GetPositionPath(kpath), table) && table {
+			walkToml(sub, key+".", f)
+		}
+	}
+}
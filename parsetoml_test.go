@@ -0,0 +1,253 @@
+package libuconf_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTomlFileFS(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+		b      = o.Int("b.c", 0, 0, "bchelp")
+	)
+	o.FS = fstest.MapFS{
+		"etc/test.toml": {Data: []byte("a = \"aval\"\n[b]\nc = 5\n")},
+	}
+
+	err := o.ParseTomlFile("/etc/test.toml")
+	assert.Nil(err)
+	assert.Equal("aval", *a)
+	assert.Equal(int64(5), *b)
+
+	// missing files are only an error when asked for directly
+	assert.NotNil(o.ParseTomlFile("/etc/missing.toml"))
+	assert.Nil(o.ParseTomlFiles("/etc/missing.toml", "etc/test.toml"))
+}
+
+func TestParseStdTomlFS(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+		b      = o.String("b", 0, "", "bhelp")
+	)
+	o.FS = fstest.MapFS{
+		"etc/test.toml":        {Data: []byte("a = \"etc\"\nb = \"etc\"\n")},
+		"etc/test/config.toml": {Data: []byte("b = \"etcdir\"\n")},
+	}
+
+	err := o.ParseStdToml()
+	assert.Nil(err)
+	assert.Equal("etc", *a)
+	assert.Equal("etcdir", *b)
+}
+
+func TestConfigPaths(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+	)
+	o.Paths = &ConfigPaths{
+		Name:   "other",
+		System: []string{"/usr/local/etc"},
+		Home:   "/home/user",
+		Extra:  []string{"./.other"},
+	}
+	o.FS = fstest.MapFS{
+		"usr/local/etc/other.toml": {Data: []byte("a = \"system\"\n")},
+		"home/user/.otherrc":       {Data: []byte("a = \"rc\"\n")},
+		".other.toml":              {Data: []byte("a = \"local\"\n")},
+	}
+
+	files, err := o.StdTomlFiles()
+	assert.Nil(err)
+	assert.Equal([]string{
+		"/usr/local/etc/other.toml",
+		"/usr/local/etc/other/config.toml",
+		"./.other.toml",
+	}, files)
+
+	err = o.ParseStdToml()
+	assert.Nil(err)
+	assert.Equal("local", *a)
+
+	// the rc file is only used when enabled
+	o.Paths.RC = true
+	o.Paths.Extra = nil
+	assert.Nil(o.ParseStdToml())
+	assert.Equal("rc", *a)
+	assert.Contains(o.Paths.Files("toml"), "/home/user/.otherrc")
+	assert.NotContains(o.Paths.Files("json"), "/home/user/.otherrc")
+}
+
+func TestParseStdTomlDropIn(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+		b      = o.String("b", 0, "", "bhelp")
+		c      = o.Int("c", 0, 0, "chelp")
+	)
+	o.Paths = &ConfigPaths{Name: "test", System: []string{"/etc"}, DropIn: "conf.d"}
+	o.FS = fstest.MapFS{
+		"etc/test.toml":            {Data: []byte("a = \"main\"\nb = \"main\"\n")},
+		"etc/test/conf.d/10.toml":  {Data: []byte("a = \"10\"\nb = \"10\"\n")},
+		"etc/test/conf.d/20.toml":  {Data: []byte("b = \"20\"\n")},
+		"etc/test/conf.d/skip.txt": {Data: []byte("a = \"txt\"\n")},
+	}
+
+	files, err := o.StdTomlFiles()
+	assert.Nil(err)
+	assert.Equal([]string{
+		"/etc/test.toml",
+		"/etc/test/config.toml",
+		"/etc/test/conf.d/10.toml",
+		"/etc/test/conf.d/20.toml",
+	}, files)
+
+	err = o.ParseStdToml()
+	assert.Nil(err)
+	assert.Equal("10", *a)
+	assert.Equal("20", *b)
+
+	// errors name the fragment
+	o.FS.(fstest.MapFS)["etc/test/conf.d/30.toml"] = &fstest.MapFile{Data: []byte("c = \"x\"\n")}
+	err = o.ParseStdToml()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "/etc/test/conf.d/30.toml")
+	assert.Equal(int64(0), *c)
+}
+
+func TestParseTomlGlobDir(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+		b      = o.String("b", 0, "", "bhelp")
+	)
+	// directories are never patterns, only file names are
+	o.Paths = &ConfigPaths{Name: "test", System: []string{"/opt/[x]"}, DropIn: "conf.d"}
+	o.FS = fstest.MapFS{
+		"opt/[x]/test/conf.d/10.toml": {Data: []byte("a = \"10\"\ninclude = \"*.inc\"\n")},
+		"opt/[x]/test/conf.d/b.inc":   {Data: []byte("b = \"inc\"\n")},
+		"opt/x/test/conf.d/20.toml":   {Data: []byte("a = \"20\"\n")},
+	}
+	files, err := o.StdTomlFiles()
+	assert.Nil(err)
+	assert.Equal([]string{
+		"/opt/[x]/test.toml",
+		"/opt/[x]/test/config.toml",
+		"/opt/[x]/test/conf.d/10.toml",
+	}, files)
+	assert.Nil(o.ParseStdToml())
+	assert.Equal("10", *a)
+	assert.Equal("inc", *b)
+
+	*a = ""
+	assert.Nil(o.ParseTomlDir("/opt/[x]/test/conf.d"))
+	assert.Equal("10", *a)
+}
+
+func TestParseTomlInclude(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		a      = o.String("a", 0, "", "ahelp")
+		b      = o.String("b", 0, "", "bhelp")
+		c      = o.String("c", 0, "", "chelp")
+	)
+	o.FS = fstest.MapFS{
+		"etc/main.toml": {Data: []byte(
+			"include = [\"common.toml\", \"secrets/*.toml\"]\na = \"main\"\n")},
+		"etc/common.toml":    {Data: []byte("a = \"common\"\nb = \"common\"\nc = \"common\"\n")},
+		"etc/secrets/1.toml": {Data: []byte("c = \"1\"\n")},
+		"etc/secrets/2.toml": {Data: []byte("include = \"../secrets/*.toml\"\nc = \"2\"\n")},
+		"etc/cycle.toml":     {Data: []byte("include = \"cycle2.toml\"\n")},
+		"etc/cycle2.toml":    {Data: []byte("include = \"/etc/cycle.toml\"\n")},
+		"etc/missing.toml":   {Data: []byte("include = \"nope.toml\"\n")},
+	}
+	for i := 0; i < 20; i++ {
+		o.FS.(fstest.MapFS)[fmt.Sprintf("etc/deep/%d.toml", i)] = &fstest.MapFile{
+			Data: []byte(fmt.Sprintf("include = \"%d.toml\"\n", i+1)),
+		}
+	}
+
+	// the includer takes precedence, then later includes
+	err := o.ParseTomlFile("/etc/main.toml")
+	assert.Nil(err)
+	assert.Equal("main", *a)
+	assert.Equal("common", *b)
+	assert.Equal("2", *c)
+
+	err = o.ParseTomlFile("/etc/cycle.toml")
+	assert.True(errors.Is(err, ErrInclude))
+	assert.Contains(err.Error(), "cycle")
+
+	// a missing include must not be mistaken for a missing file
+	err = o.ParseTomlFiles("/etc/missing.toml")
+	assert.True(errors.Is(err, ErrInclude))
+
+	err = o.ParseTomlFile("/etc/deep/0.toml")
+	assert.True(errors.Is(err, ErrInclude))
+	assert.Contains(err.Error(), "too deeply")
+
+	// the cause of a failed include is kept, and the file named once
+	o.FS.(fstest.MapFS)["etc/bad.toml"] = &fstest.MapFile{Data: []byte("include = \"c.toml\"\n")}
+	o.FS.(fstest.MapFS)["etc/c.toml"] = &fstest.MapFile{Data: []byte("x = 1\n")}
+	o.StrictToml = StrictError
+	err = o.ParseTomlFile("/etc/bad.toml")
+	assert.True(errors.Is(err, ErrInclude))
+	assert.True(errors.Is(err, ErrUnknownKey))
+	assert.EqualError(err, "failed to include: /etc/c.toml: unknown key: line 1: x (did you mean a or b or c?)")
+
+	o.StrictToml = Permissive
+	o.Int("n", 0, 0, "nhelp")
+	o.FS.(fstest.MapFS)["etc/c.toml"] = &fstest.MapFile{Data: []byte("n = \"x\"\n")}
+	err = o.ParseTomlFile("/etc/bad.toml")
+	assert.True(errors.Is(err, ErrInclude))
+	assert.True(errors.Is(err, ErrSet))
+}
+
+func TestParseTomlStrict(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test"}
+		host   = o.String("database.host", 0, "", "hosthelp")
+		port   = o.Int("database.port", 0, 0, "porthelp")
+		data   = "[database]\ndatabse = 1\nport = 5\n\n[databse]\nhost = \"x\"\n"
+		warns  []error
+	)
+
+	// permissive by default
+	assert.Nil(o.ParseTomlString(data))
+	assert.Equal(int64(5), *port)
+
+	*port = 0
+	o.StrictToml = StrictError
+	err := o.ParseTomlString(data)
+	assert.True(errors.Is(err, ErrUnknownKey))
+	assert.Contains(err.Error(), "line 2: database.databse; ")
+	assert.Contains(err.Error(), "line 6: databse.host (did you mean database.host?)")
+	assert.Equal(int64(0), *port) // nothing gets set
+
+	o.StrictToml = StrictWarn
+	o.Warn = func(err error) { warns = append(warns, err) }
+	o.FS = fstest.MapFS{"test.toml": {Data: []byte(data)}}
+	assert.Nil(o.ParseTomlFile("test.toml"))
+	assert.Equal(int64(5), *port)
+	assert.Equal("", *host)
+	assert.Len(warns, 2)
+	for _, v := range warns {
+		assert.True(errors.Is(v, ErrUnknownKey))
+		assert.Contains(v.Error(), "test.toml: ")
+	}
+}
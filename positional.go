@@ -0,0 +1,110 @@
+package libuconf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Arity describes how many arguments a positional argument takes.
+type Arity int
+
+// Possible Arity values.
+const (
+	Required Arity = iota // exactly one argument
+	Optional              // zero or one argument
+	Variadic              // any number of arguments, each passed to Set
+)
+
+// positional is a positional argument, as declared with Positional.
+type positional struct {
+	name  string
+	arity Arity
+	opt   Setter
+}
+
+/*
+Positional declares a named positional argument, to be set by ParseArgs.
+Any Setter may be used, for example an option made with NewIntOpt.
+
+Arguments are assigned in the order they are declared.
+Required arguments always get one, and Optional arguments get one if there are
+enough to go around.
+A Variadic argument gets all of the rest, and has Set called once for each.
+*/
+func (o *OptionSet) Positional(name string, arity Arity, opt Setter) {
+	o.positionals = append(o.positionals, positional{name, arity, opt})
+}
+
+/*
+ParseArgs sets the positional arguments declared with Positional from Args.
+If none were declared, it does nothing.
+
+If there are not enough Args for the Required arguments, ErrNoVal is returned.
+If there are too many and there is no Variadic argument, ErrExtraArg is.
+*/
+func (o *OptionSet) ParseArgs() error {
+	if len(o.positionals) == 0 {
+		return nil
+	}
+	required := 0
+	for _, p := range o.positionals {
+		if p.arity == Required {
+			required++
+		}
+	}
+
+	args := o.Args
+	for _, p := range o.positionals {
+		n := 0
+		switch p.arity {
+		case Required:
+			required--
+			n = 1
+		case Optional:
+			if len(args) > required {
+				n = 1
+			}
+		case Variadic:
+			if n = len(args) - required; n < 0 {
+				n = 0
+			}
+		}
+		if len(args) < n {
+			return errNoVal(p.String())
+		}
+		for _, v := range args[:n] {
+			src := fmt.Sprintf("arg %d", len(o.Args)-len(args)+1)
+			if err := o.set(p.opt, v, src); err != nil {
+				return errSet(p.opt, p.String(), v, err)
+			}
+			args = args[1:]
+		}
+	}
+	if len(args) > 0 {
+		return errExtraArg(args)
+	}
+	return nil
+}
+
+// String returns the argument as shown in Usage, e.g "<name>" or "[name...]".
+func (p positional) String() string {
+	switch p.arity {
+	case Optional:
+		return "[" + p.name + "]"
+	case Variadic:
+		return "[" + p.name + "...]"
+	}
+	return "<" + p.name + ">"
+}
+
+// synopsis returns a usage line such as "app [flags] <src> [dst...]".
+func (o *OptionSet) synopsis() string {
+	var s strings.Builder
+	s.WriteString(o.AppName)
+	s.WriteString(" [flags]")
+	for _, p := range o.positionals {
+		s.WriteRune(' ')
+		s.WriteString(p.String())
+	}
+	return s.String()
+}
@@ -0,0 +1,93 @@
+package libuconf_test
+
+import (
+	"errors"
+	"testing"
+
+	. "toast.cafe/x/libuconf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// appendOpt collects every value it is set to.
+type appendOpt []string
+
+func (r *appendOpt) Set(vv interface{}) error {
+	*r = append(*r, vv.(string))
+	return nil
+}
+
+// funcOpt is a Setter that cannot be compared.
+type funcOpt func(interface{}) error
+
+func (r funcOpt) Set(vv interface{}) error {
+	return r(vv)
+}
+
+func TestArg(t *testing.T) {
+	assert := assert.New(t)
+	o := &OptionSet{AppName: "test", Args: []string{"a"}}
+	assert.Equal("a", o.Arg(0))
+	assert.Equal("", o.Arg(1))
+	assert.Equal("", o.Arg(-1))
+}
+
+func TestParseArgs(t *testing.T) {
+	matrix := []struct {
+		args     []string
+		src, dst string
+		count    int64
+		files    []string
+		err      error
+	}{
+		{[]string{"a", "b"}, "a", "b", 0, nil, nil},
+		{[]string{"a", "b", "5"}, "a", "b", 5, nil, nil},
+		{[]string{"a", "b", "5", "c", "d"}, "a", "b", 5, []string{"c", "d"}, nil},
+		{[]string{"a"}, "a", "", 0, nil, ErrNoVal},
+		{[]string{"a", "b", "x"}, "a", "b", 0, nil, ErrSet},
+	}
+	for _, v := range matrix {
+		var (
+			assert      = assert.New(t)
+			o           = &OptionSet{AppName: "test", Args: v.args}
+			src, srcv   = NewStringOpt("src", 0, "", "srchelp")
+			dst, dstv   = NewStringOpt("dst", 0, "", "dsthelp")
+			count, cntv = NewIntOpt("count", 0, 0, "counthelp")
+			files       appendOpt
+		)
+		o.Positional("src", Required, src)
+		o.Positional("dst", Required, dst)
+		o.Positional("count", Optional, count)
+		o.Positional("files", Variadic, &files)
+
+		err := o.ParseArgs()
+		if v.err != nil {
+			assert.True(errors.Is(err, v.err), v.args)
+			continue
+		}
+		assert.Nil(err, v.args)
+		assert.Equal(v.src, *srcv)
+		assert.Equal(v.dst, *dstv)
+		assert.Equal(v.count, *cntv)
+		assert.Equal(v.files, []string(files))
+		assert.Equal("arg 2", o.Source(dst))
+	}
+
+	// without a variadic argument, extra arguments are an error
+	o := &OptionSet{AppName: "test", Args: []string{"a", "b"}}
+	o.Positional("src", Required, new(appendOpt))
+	assert.True(t, errors.Is(o.ParseArgs(), ErrExtraArg))
+}
+
+func TestParseArgsUncomparable(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		o      = &OptionSet{AppName: "test", Args: []string{"a"}}
+		got    interface{}
+		opt    = funcOpt(func(vv interface{}) error { got = vv; return nil })
+	)
+	o.Positional("x", Required, opt)
+	assert.Nil(o.ParseArgs())
+	assert.Equal("a", got)
+	assert.Equal("", o.Source(opt))
+}
@@ -0,0 +1,126 @@
+package libuconf
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// how deep response files may nest
+const maxResponseDepth = 16
+
+// respArg is an argument to ParseFlags, and the response file it came from.
+type respArg struct {
+	s     string
+	file  string // empty if it was given directly
+	line  int    // where s starts in file
+	depth int    // how deeply nested file is
+}
+
+// isResponse reports whether s names a response file ("@file").
+func isResponse(s string) bool {
+	return len(s) > 1 && s[0] == '@'
+}
+
+/*
+expandArg returns the arguments contained in the response file a names.
+Relative paths are resolved against the response file a came from, if any.
+
+Response files are split like a shell would: arguments are separated by
+whitespace, single quotes preserve everything within them, double quotes
+preserve everything but backslash escapes (\" and \\), and a backslash outside
+of quotes escapes the next character.
+A "#" at the start of an argument comments out the rest of the line.
+*/
+func (o *OptionSet) expandArg(a respArg) ([]respArg, error) {
+	file := a.s[1:]
+	if a.file != "" && !path.IsAbs(file) {
+		file = path.Join(path.Dir(a.file), file)
+	}
+	if a.depth >= maxResponseDepth {
+		return nil, errResponse(file, 0, errors.New("nested too deeply"))
+	}
+	b, err := o.readFile(file)
+	if err != nil {
+		return nil, errResponse(file, 0, err)
+	}
+	out, err := splitResponse(file, string(b))
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		out[i].file, out[i].depth = file, a.depth+1
+	}
+	return out, nil
+}
+
+// splitResponse splits the contents of a response file into arguments, along
+// with the lines they start on.
+// See expandArg for the rules.
+func splitResponse(name, c string) ([]respArg, error) {
+	var (
+		out   []respArg
+		cur   strings.Builder
+		inArg bool // we have an argument, even if it's empty ("")
+		aline int  // the line the argument started on
+		quote rune // the quote we're in, if any
+		qline int  // the line the quote started on
+		line  = 1
+		esc   bool // the previous character was a backslash
+		cmt   bool // we're in a comment
+	)
+	for _, v := range c {
+		if v == '\n' {
+			line++
+		}
+		switch {
+		case cmt:
+			cmt = v != '\n'
+		case esc:
+			esc = false
+			if v == '\n' && quote == 0 { // line continuation
+				continue
+			}
+			if quote == '"' && v != '"' && v != '\\' {
+				cur.WriteRune('\\')
+			}
+			if !inArg {
+				inArg, aline = true, line
+			}
+			cur.WriteRune(v)
+		case v == '\\' && quote != '\'':
+			esc = true
+		case quote != 0 && v == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteRune(v)
+		case v == '\'' || v == '"':
+			if !inArg {
+				inArg, aline = true, line
+			}
+			quote, qline = v, line
+		case v == ' ' || v == '\t' || v == '\n' || v == '\r':
+			if inArg {
+				out = append(out, respArg{s: cur.String(), line: aline})
+				cur.Reset()
+				inArg = false
+			}
+		case v == '#' && !inArg:
+			cmt = true
+		default:
+			if !inArg {
+				inArg, aline = true, line
+			}
+			cur.WriteRune(v)
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, errResponse(name, qline, errors.New("unterminated quote"))
+	case esc:
+		return nil, errResponse(name, line, errors.New("trailing backslash"))
+	case inArg:
+		out = append(out, respArg{s: cur.String(), line: aline})
+	}
+	return out, nil
+}
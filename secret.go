@@ -0,0 +1,141 @@
+package libuconf
+
+import "fmt"
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*SecretOpt)(nil)
+	_ EnvOpt  = (*SecretOpt)(nil)
+	_ FlagOpt = (*SecretOpt)(nil)
+	_ Getter  = (*SecretOpt)(nil)
+	_ Setter  = (*SecretOpt)(nil)
+	_ TomlOpt = (*SecretOpt)(nil)
+)
+
+// what secrets look like when displayed
+const redacted = "<redacted>"
+
+// secret describes an option whose value must not be displayed.
+// Libuconf redacts the values of such options in its error messages.
+type secret interface {
+	Secret() string
+}
+
+// redacter describes an option whose values might hold secrets, such as a
+// URL with a password.
+// Libuconf displays its values in error messages as redact returns them.
+type redacter interface {
+	redact(val string) string
+}
+
+/*
+SecretOpt represents a string Option holding a secret, such as an API token.
+
+Its value is redacted everywhere libuconf might display it, such as Usage and
+error messages, as Get only returns a placeholder.
+Use Secret (or the pointer returned by NewSecretOpt) to get the real value.
+
+Like any other option, it may be allowed to read its value from a file using
+OptionSet.FileValues (see FileValueOpt).
+*/
+type SecretOpt struct {
+	help  string
+	name  string
+	sname rune
+	val   *string
+}
+
+// NewSecretOpt instantiates a SecretOpt and returns needed implementation details.
+func NewSecretOpt(name string, sname rune, val string, help string) (*SecretOpt, *string) {
+	out := SecretOpt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// Secret returns the actual value of the option.
+func (r *SecretOpt) Secret() string {
+	return *r.val
+}
+
+// String returns the redacted value of the option, so it's safe to print.
+func (r *SecretOpt) String() string {
+	if *r.val == "" {
+		return ""
+	}
+	return redacted
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *SecretOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *SecretOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*SecretOpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *SecretOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *SecretOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *SecretOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the redacted value (see String).
+// This is primarily used in Usage() to show the current value for options.
+func (r *SecretOpt) Get() interface{} {
+	return r.String()
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *SecretOpt) Set(vv interface{}) error {
+	switch v := vv.(type) {
+	case string:
+		*r.val = v
+	default:
+		*r.val = fmt.Sprint(vv)
+	}
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *SecretOpt) Toml() string {
+	return _toml(r)
+}
@@ -0,0 +1,53 @@
+package libuconf
+
+import "reflect"
+
+// set sets opt to val, recording src as its source if it succeeds.
+func (o *OptionSet) set(opt Setter, val interface{}, src string) error {
+	if err := opt.Set(val); err != nil {
+		return err
+	}
+	if f, ok := opt.(FlagOpt); ok { // aliases set their target
+		opt = unalias(f)
+	}
+	if !hashable(opt) {
+		return nil
+	}
+	if o.sources == nil {
+		o.sources = make(map[Setter]string)
+	}
+	o.sources[opt] = src
+	return nil
+}
+
+/*
+Source returns where an option was last set from by the Parse family.
+If it was never set (meaning it still has its default value), it returns an
+empty string.
+
+Sources look like this:
+
+	flag --a.b.c       (ParseFlags, the flag as it was given)
+	env APP_A_B_C      (ParseEnv)
+	file /etc/app.toml (ParseTomlFile and friends)
+	toml string        (ParseTomlString)
+	arg 1              (ParseArgs, counting from 1)
+
+Values read from files (see FileValueOpt) look like "file /run/secrets/x (env
+APP_X_FILE)", naming both the file and where it was named.
+
+Options that cannot be compared (such as a func type implementing Setter)
+have no source.
+*/
+func (o *OptionSet) Source(opt Setter) string {
+	if !hashable(opt) {
+		return ""
+	}
+	return o.sources[opt]
+}
+
+// hashable reports whether v can be used as a map key.
+// Options are usually pointers, but nothing forces them to be.
+func hashable(v interface{}) bool {
+	return v != nil && reflect.TypeOf(v).Comparable()
+}
@@ -0,0 +1,109 @@
+package libuconf
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+Strictness describes how a Parse function handles input that does not
+correspond to any registered option, such as a misspelled TOML key.
+
+Permissive is the default, and ignores such input.
+StrictWarn reports each unknown input to OptionSet.Warn (see OptionSet), and
+otherwise continues as Permissive would.
+StrictError makes the Parse function return an error listing all of the unknown
+input, without setting anything from it.
+*/
+type Strictness int
+
+// Possible Strictness values.
+const (
+	Permissive Strictness = iota
+	StrictWarn
+	StrictError
+)
+
+// contains reports whether s is in ss.
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	var (
+		ra, rb = []rune(a), []rune(b)
+		prev   = make([]int, len(rb)+1)
+		cur    = make([]int, len(rb)+1)
+	)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range ra {
+		cur[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			cur[j+1] = min3(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// suggest returns the candidates closest to s, if any are close enough.
+// "Close enough" means within a third of the length of s (but at least 1).
+func suggest(s string, candidates []string) []string {
+	var (
+		out  []string
+		best = len([]rune(s)) / 3
+	)
+	if best < 1 {
+		best = 1
+	}
+	for _, c := range candidates {
+		d := levenshtein(s, c)
+		switch {
+		case d > best:
+			continue
+		case d < best:
+			best = d
+			out = out[:0]
+		}
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	for i := len(out) - 1; i > 0; i-- { // dedup
+		if out[i] == out[i-1] {
+			out = append(out[:i], out[i+1:]...)
+		}
+	}
+	return out
+}
+
+// didYouMean formats suggestions for s, for use in an error message.
+// It returns an empty string if there are no suggestions.
+func didYouMean(s string, candidates []string) string {
+	sug := suggest(s, candidates)
+	if len(sug) == 0 {
+		return ""
+	}
+	return " (did you mean " + strings.Join(sug, " or ") + "?)"
+}
@@ -0,0 +1,136 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*Uint32Opt)(nil)
+	_ EnvOpt  = (*Uint32Opt)(nil)
+	_ FlagOpt = (*Uint32Opt)(nil)
+	_ Getter  = (*Uint32Opt)(nil)
+	_ Setter  = (*Uint32Opt)(nil)
+	_ TomlOpt = (*Uint32Opt)(nil)
+)
+
+// Uint32Opt represents an unsigned 32-bit integer Option.
+type Uint32Opt struct {
+	help  string
+	name  string
+	sname rune
+	val   *uint32
+}
+
+// NewUint32Opt instantiates a Uint32Opt and returns needed implementation details.
+func NewUint32Opt(name string, sname rune, val uint32, help string) (*Uint32Opt, *uint32) {
+	out := Uint32Opt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *Uint32Opt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *Uint32Opt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*Uint32Opt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *Uint32Opt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *Uint32Opt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *Uint32Opt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *Uint32Opt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *Uint32Opt) Set(vv interface{}) error {
+	var (
+		val uint32
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseUint[uint32](v)
+	case uint32:
+		val = v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint64, float32, float64:
+		val, err = toUint[uint32](v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *Uint32Opt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Uint32 adds a Uint32Opt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Uint32(name string, sname rune, val uint32, help string) *uint32 {
+	op, v := NewUint32Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// Uint32Var adds a Uint32Opt to the OptionSet.
+func (o *OptionSet) Uint32Var(out *uint32, name string, sname rune, val uint32, help string) {
+	*out = val
+	op := &Uint32Opt{help, name, sname, out}
+	o.Var(op)
+}
@@ -0,0 +1,136 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
+package libuconf
+
+import (
+	"fmt"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*Uint8Opt)(nil)
+	_ EnvOpt  = (*Uint8Opt)(nil)
+	_ FlagOpt = (*Uint8Opt)(nil)
+	_ Getter  = (*Uint8Opt)(nil)
+	_ Setter  = (*Uint8Opt)(nil)
+	_ TomlOpt = (*Uint8Opt)(nil)
+)
+
+// Uint8Opt represents an unsigned 8-bit integer Option.
+type Uint8Opt struct {
+	help  string
+	name  string
+	sname rune
+	val   *uint8
+}
+
+// NewUint8Opt instantiates a Uint8Opt and returns needed implementation details.
+func NewUint8Opt(name string, sname rune, val uint8, help string) (*Uint8Opt, *uint8) {
+	out := Uint8Opt{help, name, sname, &val}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *Uint8Opt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *Uint8Opt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*Uint8Opt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *Uint8Opt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *Uint8Opt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *Uint8Opt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the internal value.
+// This is primarily used in Usage() to show the current value for options.
+func (r *Uint8Opt) Get() interface{} {
+	return *r.val
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *Uint8Opt) Set(vv interface{}) error {
+	var (
+		val uint8
+		err error
+	)
+	switch v := vv.(type) {
+	case string:
+		val, err = parseUint[uint8](v)
+	case uint8:
+		val = v
+	case int, int8, int16, int32, int64, uint, uint16, uint32, uint64, float32, float64:
+		val, err = toUint[uint8](v)
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
+	return nil
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *Uint8Opt) Toml() string {
+	return _toml(r)
+}
+
+// ---- integration
+
+// Uint8 adds a Uint8Opt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Uint8(name string, sname rune, val uint8, help string) *uint8 {
+	op, v := NewUint8Opt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// Uint8Var adds a Uint8Opt to the OptionSet.
+func (o *OptionSet) Uint8Var(out *uint8, name string, sname rune, val uint8, help string) {
+	*out = val
+	op := &Uint8Opt{help, name, sname, out}
+	o.Var(op)
+}
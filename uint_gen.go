@@ -1,18 +1,20 @@
+// Code generated by uconfgen; DO NOT EDIT.
+
 package libuconf
 
 import (
 	"fmt"
-	"reflect"
-	"strconv"
 )
 
 // ensure interface compliance
 var (
-	_ EnvOpt  = (*UintOpt)(nil)
-	_ FlagOpt = (*UintOpt)(nil)
-	_ Getter  = (*UintOpt)(nil)
-	_ Setter  = (*UintOpt)(nil)
-	_ TomlOpt = (*UintOpt)(nil)
+	_ DirOpt       = (*UintOpt)(nil)
+	_ EnvOpt       = (*UintOpt)(nil)
+	_ FlagOpt      = (*UintOpt)(nil)
+	_ Getter       = (*UintOpt)(nil)
+	_ NegatableOpt = (*UintOpt)(nil)
+	_ Setter       = (*UintOpt)(nil)
+	_ TomlOpt      = (*UintOpt)(nil)
 )
 
 // UintOpt represents an unsigned 64-bit integer Option.
@@ -29,6 +31,15 @@ func NewUintOpt(name string, sname rune, val uint64, help string) (*UintOpt, *ui
 	return &out, out.val
 }
 
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *UintOpt) File() string {
+	return _file(r)
+}
+
 // ---- EnvOpt
 
 // Env returns the option's environment search string.
@@ -73,29 +84,39 @@ func (r *UintOpt) Get() interface{} {
 	return *r.val
 }
 
+// ---- NegatableOpt
+
+// Negatable returns whether or not "--no-flag" is valid.
+//
+// This is always false for UintOpt, even though Bool returns true.
+func (*UintOpt) Negatable() bool {
+	return false
+}
+
 // ---- Setter
 
 // Set sets this option's value.
 // It should be able to handle whatever type it might receive, which means it
 // must at least handle strings for being usable in ParseFlags.
 func (r *UintOpt) Set(vv interface{}) error {
-	val := reflect.ValueOf(vv)
+	var (
+		val uint64
+		err error
+	)
 	switch v := vv.(type) {
 	case string:
-		i, e := strconv.ParseUint(v, 0, 0)
-		if e != nil {
-			return fmt.Errorf("%w: to %+v", ErrSet, v)
-		}
-		*r.val = i
-	case int8, int16, int32, int64, int:
-		*r.val = uint64(val.Int())
-	case uint8, uint16, uint32, uint64, uint:
-		*r.val = val.Uint()
-	case float32, float64:
-		*r.val = uint64(val.Float()) // WARNING: WILL TRUNCATE
+		val, err = parseUint[uint64](v)
+	case uint64:
+		val = v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, float32, float64:
+		val, err = toUint[uint64](v)
 	default:
 		return fmt.Errorf("%w: to %+v", ErrSet, vv)
 	}
+	if err != nil {
+		return errSetTo(vv, err)
+	}
+	*r.val = val
 	return nil
 }
 
@@ -106,3 +127,20 @@ func (r *UintOpt) Set(vv interface{}) error {
 func (r *UintOpt) Toml() string {
 	return _toml(r)
 }
+
+// ---- integration
+
+// Uint adds a UintOpt to the OptionSet.
+// It returns a pointer to the output value.
+func (o *OptionSet) Uint(name string, sname rune, val uint64, help string) *uint64 {
+	op, v := NewUintOpt(name, sname, val, help)
+	o.Var(op)
+	return v
+}
+
+// UintVar adds a UintOpt to the OptionSet.
+func (o *OptionSet) UintVar(out *uint64, name string, sname rune, val uint64, help string) {
+	*out = val
+	op := &UintOpt{help, name, sname, out}
+	o.Var(op)
+}
@@ -0,0 +1,179 @@
+package libuconf
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ensure interface compliance
+var (
+	_ DirOpt  = (*URLOpt)(nil)
+	_ EnvOpt  = (*URLOpt)(nil)
+	_ FlagOpt = (*URLOpt)(nil)
+	_ Getter  = (*URLOpt)(nil)
+	_ Setter  = (*URLOpt)(nil)
+	_ TomlOpt = (*URLOpt)(nil)
+)
+
+// URLOpt represents a URL Option.
+// If it is given a list of schemes, the URL must use one of them.
+type URLOpt struct {
+	help    string
+	name    string
+	schemes []string
+	sname   rune
+	val     *url.URL
+}
+
+// NewURLOpt instantiates a URLOpt and returns needed implementation details.
+// val may be nil, in which case the URL is empty.
+// If any schemes are given, the URL must use one of them, such as "https".
+func NewURLOpt(name string, sname rune, val *url.URL, help string, schemes ...string) (*URLOpt, *url.URL) {
+	out := URLOpt{help, name, schemes, sname, new(url.URL)}
+	if val != nil {
+		*out.val = *val
+	}
+	return &out, out.val
+}
+
+// ---- DirOpt
+
+// File returns the option's file name within a directory.
+// For example, if File() returns "foo", ParseDir("/dir") will read the file
+// "/dir/foo".
+func (r *URLOpt) File() string {
+	return _file(r)
+}
+
+// ---- EnvOpt
+
+// Env returns the option's environment search string.
+// For example, if the app name is "APP" and Env() returns "FOO", we will look
+// for the environment variable "APP_FOO".
+func (r *URLOpt) Env() string {
+	return env(r)
+}
+
+// ---- FlagOpt
+
+// Bool returns whether or not this option is a boolean.
+// This is important because ParseFlags() will handle them differently.
+func (*URLOpt) Bool() bool {
+	return false
+}
+
+// Flag returns the long-form flag for this option.
+// All strings are valid, but non-printable ones aren't useful.
+func (r *URLOpt) Flag() string {
+	return r.name
+}
+
+// Help returns the help string for this option.
+// It is only used in the Usage() call.
+func (r *URLOpt) Help() string {
+	return r.help
+}
+
+// ShortFlag returns the short-form flag for this option.
+// All runes are valid, but non-printable ones aren't useful.
+// 0 means "disabled".
+func (r *URLOpt) ShortFlag() rune {
+	return r.sname
+}
+
+// ---- Getter
+
+// Get returns the URL as a string, with its password redacted (see String).
+// This is primarily used in Usage() to show the current value for options.
+func (r *URLOpt) Get() interface{} {
+	return r.String()
+}
+
+// String returns the URL with its password, if any, redacted.
+// Use the pointer returned by NewURLOpt to get the real value.
+func (r *URLOpt) String() string {
+	return r.val.Redacted()
+}
+
+// ---- Setter
+
+// Set sets this option's value.
+// It should be able to handle whatever type it might receive, which means it
+// must at least handle strings for being usable in ParseFlags.
+func (r *URLOpt) Set(vv interface{}) error {
+	var (
+		u   *url.URL
+		err error
+		e   *url.Error
+	)
+	switch v := vv.(type) {
+	case string:
+		if u, err = url.Parse(v); errors.As(err, &e) {
+			if redactURL(v) != v { // e repeats v as-is, password and all
+				return errSetTo(redactURL(v), errors.New("invalid URL"))
+			}
+			return errSetTo(v, e.Err)
+		}
+	case url.URL:
+		u = &v
+	case *url.URL:
+		u = v
+	default:
+		return fmt.Errorf("%w: to %+v", ErrSet, vv)
+	}
+	if err = r.checkScheme(u); err != nil {
+		if u == nil {
+			return errSetTo(vv, err)
+		}
+		return errSetTo(redactURL(u.String()), err)
+	}
+	*r.val = *u
+	return nil
+}
+
+// checkScheme ensures u uses one of the allowed schemes, if there are any.
+func (r *URLOpt) checkScheme(u *url.URL) error {
+	if u == nil {
+		return errors.New("nil URL")
+	}
+	if len(r.schemes) == 0 {
+		return nil
+	}
+	for _, v := range r.schemes {
+		if strings.EqualFold(u.Scheme, v) {
+			return nil
+		}
+	}
+	return fmt.Errorf("scheme must be one of %s", strings.Join(r.schemes, ", "))
+}
+
+// redact hides the password in val, for errSet.
+func (*URLOpt) redact(val string) string {
+	return redactURL(val)
+}
+
+// redactURL is like url.URL.Redacted, for strings that might not parse.
+// As the password might hold any of "/?#@", everything up to the last "@" is
+// taken as the user info, even if that hides more than needed.
+func redactURL(s string) string {
+	i := strings.Index(s, "://")
+	if i < 0 {
+		return s
+	}
+	rest := s[i+3:]
+	at := strings.LastIndex(rest, "@")
+	if c := strings.Index(rest, ":"); c >= 0 && c < at {
+		return s[:i+3] + rest[:c+1] + "xxxxx" + rest[at:]
+	}
+	return s
+}
+
+// ---- TomlOpt
+
+// Toml returns the option's config file search string.
+// It's passed as-is to toml.Tree.Get().
+func (r *URLOpt) Toml() string {
+	return _toml(r)
+}